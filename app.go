@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"slices"
 	"time"
 
 	"galaxies-client/internal/game" // Import local game logic
@@ -38,10 +39,27 @@ func (a *App) startup(ctx context.Context) {
 			case <-a.ctx.Done():
 				return
 			case <-ticker.C:
+				if changed := game.UpdateMarketEvents(); len(changed) > 0 {
+					runtime.EventsEmit(a.ctx, "market_event", changed)
+				}
+
 				updatedPlanets := game.ReplenishMarket()
+				for _, key := range append(game.SimulateNPCs(), game.RunFleetOrders()...) {
+					if !slices.Contains(updatedPlanets, key) {
+						updatedPlanets = append(updatedPlanets, key)
+					}
+				}
 				if len(updatedPlanets) > 0 {
 					runtime.EventsEmit(a.ctx, "market_pulse", updatedPlanets)
 				}
+
+				a.checkAchievements()
+				if defaulted := game.UpdateLoans(); len(defaulted) > 0 {
+					runtime.EventsEmit(a.ctx, "loan_default", defaulted)
+					a.checkStranded()
+				}
+
+				a.flushNews()
 			}
 		}
 	}()
@@ -53,15 +71,17 @@ func (a *App) startup(ctx context.Context) {
 
 // NewGameParams defines the data needed to start a fresh journey
 type NewGameParams struct {
-	Slot        int    `json:"slot"`
-	PlayerName  string `json:"player_name"`
-	ShipName    string `json:"ship_name"`
-	ShipTypeKey string `json:"ship_type_key"`
+	Slot         int    `json:"slot"`
+	PlayerName   string `json:"player_name"`
+	ShipName     string `json:"ship_name"`
+	ShipTypeKey  string `json:"ship_type_key"`
+	AIDifficulty string `json:"ai_difficulty"` // Rival strength; empty = default
 }
 
 // CreateNewGame initializes a new player and ship based on onboarding choices
 func (a *App) CreateNewGame(params NewGameParams) string {
-	// Initialize the market for a fresh start
+	// Start from a clean sector, then seed the job boards
+	game.ResetWorld()
 	game.ReplenishMarket()
 
 	// Logic to initialize game.CurrentPlayer and game.CurrentPlayer.ActiveShip
@@ -71,6 +91,11 @@ func (a *App) CreateNewGame(params NewGameParams) string {
 		return "CREATION FAILED: " + err.Error()
 	}
 
+	// Seat the rival captains at the chosen AI strength
+	if err := game.InitializeRivals(params.AIDifficulty); err != nil {
+		return "CREATION FAILED: " + err.Error()
+	}
+
 	// Save immediately to the chosen slot
 	return a.SaveGame(params.Slot)
 }
@@ -85,6 +110,13 @@ func (a *App) SaveGame(slot int) string {
 	return "GAME SAVED"
 }
 
+// GetAIDifficulties lists the rival strengths offered on the new-game screen
+func (a *App) GetAIDifficulties() []game.AIDifficulty {
+	game.DataLock.RLock()
+	defer game.DataLock.RUnlock()
+	return game.CurrentUniverse.RivalConfig.Difficulties
+}
+
 // LoadGame triggers a load from a specific slot file
 func (a *App) LoadGame(slot int) string {
 	filename := fmt.Sprintf("save_slot_%d.yaml", slot)
@@ -109,12 +141,66 @@ func getActiveShip() *game.Ship {
 	return game.CurrentPlayer.Ships[key]
 }
 
+// gameOver reports whether the player is bankrupt. Every action that changes
+// the game is refused until a new game is started or a save is loaded.
+// Note: Caller must hold DataLock
+func gameOver() bool {
+	return game.CurrentPlayer.Bankrupt
+}
+
+// arrivalHeld reports whether the active ship's deliveries are held for a
+// pending event. Its manifest must not change until ResolveEvent lands it.
+// Note: Caller must hold DataLock
+func arrivalHeld() bool {
+	return game.CurrentPlayer.PendingEvent != nil
+}
+
 func (a *App) enrichShipData(s *game.Ship) *game.Ship {
 	s.TotalMass = game.CalculateTotalMass(s)
 	s.CurrentBurn = game.CalculateCurrentBurn(s)
 	return s
 }
 
+// flushNews pushes freshly published headlines to the UI.
+// It takes DataLock itself, so methods holding the lock must defer it
+// *before* their own Lock/Unlock pair to run after the unlock.
+func (a *App) flushNews() {
+	if items := game.DrainNews(); len(items) > 0 {
+		runtime.EventsEmit(a.ctx, "news_published", items)
+	}
+}
+
+// checkAchievements unlocks newly earned achievements and tells the UI.
+// Like flushNews it takes DataLock itself, so defer it before Lock.
+func (a *App) checkAchievements() {
+	for _, ach := range game.EvaluateAchievements() {
+		runtime.EventsEmit(a.ctx, "achievement_unlocked", ach)
+	}
+}
+
+// afterAction runs after every player action that changes the game:
+// achievements earned by the action unlock straight away, and any
+// headlines it produced reach the UI. Defer it before Lock, like flushNews.
+func (a *App) afterAction() {
+	a.checkAchievements()
+	a.flushNews()
+}
+
+// checkStranded warns the UI when the active ship can no longer fly anywhere,
+// or declares the game over when nothing can rescue it. Like flushNews it
+// takes DataLock itself.
+func (a *App) checkStranded() {
+	game.DataLock.Lock()
+	status := game.CheckBankruptcy(getActiveShip())
+	game.DataLock.Unlock()
+
+	if status.Bankrupt {
+		runtime.EventsEmit(a.ctx, "game_over", status)
+	} else if status.Stranded {
+		runtime.EventsEmit(a.ctx, "ship_stranded", status)
+	}
+}
+
 // -----------------------------------------------------------------------------
 // SHIP & NAVIGATION METHODS
 // -----------------------------------------------------------------------------
@@ -144,10 +230,28 @@ func (a *App) GetPlanets() []game.Planet {
 	return game.CurrentUniverse.Planets
 }
 
+type TravelResponse struct {
+	Success      bool                `json:"success"`
+	State        PlayerStateResponse `json:"state"`
+	Events       []game.TravelEvent  `json:"events"`
+	Duration     int64               `json:"duration_seconds"`
+	Error        string              `json:"error,omitempty"`
+	PendingEvent *game.PendingEvent  `json:"pending_event,omitempty"` // A decision raised on arrival
+}
+
 func (a *App) Travel(destinationKey string) TravelResponse {
+	defer a.afterAction()
+	defer a.checkStranded()
 	game.DataLock.Lock()
 	defer game.DataLock.Unlock()
 
+	if gameOver() {
+		return TravelResponse{Success: false, Error: "Bankrupt"}
+	}
+	if game.CurrentPlayer.PendingEvent != nil {
+		return TravelResponse{Success: false, Error: "Event Pending", PendingEvent: game.CurrentPlayer.PendingEvent}
+	}
+
 	ship := getActiveShip()
 	dest := game.GetPlanet(destinationKey)
 	curr := game.GetPlanet(ship.LocationKey)
@@ -166,21 +270,16 @@ func (a *App) Travel(destinationKey string) TravelResponse {
 
 	ship.Fuel -= cost
 	ship.LocationKey = dest.Key
+	game.AddEngineWear(ship, dist)
 
-	events := game.ProcessArrivalEvents(ship)
+	events := game.ProcessArrivalEvents(ship, curr.Key, dist)
 
-	payout := 0
-	remaining := []game.Contract{}
-	for _, c := range ship.ActiveContracts {
-		if c.DestinationKey == dest.Key {
-			payout += c.Payout
-			game.Market.RecordDelivery(c.DestinationKey, c.ItemKey, c.Quantity)
-		} else {
-			remaining = append(remaining, c)
-		}
+	// A decision raised on landing holds the delivery until it is resolved
+	if pending := game.CurrentPlayer.PendingEvent; pending != nil {
+		pending.FuelBurned = cost
+	} else {
+		game.CompleteArrival(ship, curr.Key, dist, cost)
 	}
-	ship.ActiveContracts = remaining
-	game.CurrentPlayer.Credits += payout
 
 	return TravelResponse{
 		Success: true,
@@ -189,8 +288,9 @@ func (a *App) Travel(destinationKey string) TravelResponse {
 			Credits:    game.CurrentPlayer.Credits,
 			Ship:       a.enrichShipData(ship),
 		},
-		Events:   events,
-		Duration: dist,
+		Events:       events,
+		Duration:     dist,
+		PendingEvent: game.CurrentPlayer.PendingEvent,
 	}
 }
 
@@ -227,24 +327,143 @@ func (a *App) GetTravelQuote(destinationKey string) TravelQuoteResponse {
 	}
 }
 
+// Refuel fills the active ship's tank at the local fuel price.
 func (a *App) Refuel() bool {
+	defer a.afterAction()
 	game.DataLock.Lock()
 	defer game.DataLock.Unlock()
 
+	if gameOver() {
+		return false
+	}
+
+	_, err := game.RefuelShip(getActiveShip(), 0)
+	return err == nil
+}
+
+// RefuelAmount buys 'units' of fuel (in hundredths, like Ship.Fuel) for the
+// active ship, capped at the free tank space.
+func (a *App) RefuelAmount(units int64) bool {
+	defer a.afterAction()
+	game.DataLock.Lock()
+	defer game.DataLock.Unlock()
+
+	if gameOver() {
+		return false
+	}
+
+	if units <= 0 {
+		return false
+	}
+	_, err := game.RefuelShip(getActiveShip(), units)
+	return err == nil
+}
+
+type FuelQuoteResponse struct {
+	PricePerUnit float64 `json:"price_per_unit"` // Credits per 1.00 unit here
+	ListPrice    int     `json:"list_price"`     // Galactic base price per unit
+	Needed       int64   `json:"needed"`         // Hundredths to fill the tank
+	FullCost     int     `json:"full_cost"`
+	Affordable   int64   `json:"affordable"` // Hundredths the player can pay for now
+	FuelDepot    bool    `json:"fuel_depot"`
+}
+
+// GetFuelQuote prices fuel where the active ship is docked.
+func (a *App) GetFuelQuote() FuelQuoteResponse {
+	game.DataLock.RLock()
+	defer game.DataLock.RUnlock()
+
 	ship := getActiveShip()
 	needed := ship.MaxFuel - ship.Fuel
-	if needed <= 0 {
-		return false
+	return FuelQuoteResponse{
+		PricePerUnit: game.LocalFuelPrice(ship.LocationKey),
+		ListPrice:    game.CurrentUniverse.BalanceConfig.FuelCostPerUnit,
+		Needed:       needed,
+		FullCost:     game.FuelCost(ship.LocationKey, needed),
+		Affordable:   game.AffordableFuel(ship.LocationKey, game.CurrentPlayer.Credits, needed),
+		FuelDepot:    game.HasService(ship.LocationKey, game.ServiceFuelDepot),
 	}
+}
+
+// GetRescueStatus reports whether the active ship is stranded and what a tow costs.
+func (a *App) GetRescueStatus() game.RescueStatus {
+	game.DataLock.RLock()
+	defer game.DataLock.RUnlock()
+	return game.GetRescueStatus(getActiveShip())
+}
+
+// RequestTow calls an emergency tow for a stranded active ship. Unpaid costs
+// become debt; if the debt would be too large the game ends in bankruptcy.
+func (a *App) RequestTow() game.RescueStatus {
+	defer a.afterAction()
+	defer a.checkStranded()
+	game.DataLock.Lock()
+	defer game.DataLock.Unlock()
+
+	status, _ := game.TowToDepot(getActiveShip())
+	return status
+}
+
+// RepayDebt pays down tow debt from credits (0 = as much as is owed).
+func (a *App) RepayDebt(amount int) bool {
+	defer a.afterAction()
+	game.DataLock.Lock()
+	defer game.DataLock.Unlock()
 
-	cost := (int(needed) / 100) * game.CurrentUniverse.BalanceConfig.FuelCostPerUnit
-	if game.CurrentPlayer.Credits < cost {
+	if gameOver() {
 		return false
 	}
 
-	game.CurrentPlayer.Credits -= cost
-	ship.Fuel = ship.MaxFuel
-	return true
+	_, err := game.RepayDebt(amount)
+	return err == nil
+}
+
+// -----------------------------------------------------------------------------
+// EVENT METHODS
+// -----------------------------------------------------------------------------
+
+type ResolveEventResponse struct {
+	Success bool                `json:"success"`
+	Event   game.TravelEvent    `json:"event"`
+	Payout  int                 `json:"payout"` // Contracts delivered once the arrival completed
+	State   PlayerStateResponse `json:"state"`
+	Error   string              `json:"error,omitempty"`
+}
+
+// GetPendingEvent returns the decision awaiting the captain, or nil.
+func (a *App) GetPendingEvent() *game.PendingEvent {
+	game.DataLock.RLock()
+	defer game.DataLock.RUnlock()
+	return game.CurrentPlayer.PendingEvent
+}
+
+// ResolveEvent answers a pending event with one of its choices.
+func (a *App) ResolveEvent(eventID, choiceID string) ResolveEventResponse {
+	defer a.afterAction()
+	defer a.checkStranded()
+	game.DataLock.Lock()
+	defer game.DataLock.Unlock()
+
+	if gameOver() {
+		return ResolveEventResponse{Success: false, Error: "Bankrupt"}
+	}
+
+	event, payout, err := game.ResolveEvent(eventID, choiceID)
+	if err != nil {
+		return ResolveEventResponse{Success: false, Error: err.Error()}
+	}
+
+	ship := getActiveShip()
+	return ResolveEventResponse{
+		Success: true,
+		Event:   event,
+		Payout:  payout,
+		State: PlayerStateResponse{
+			PlayerName: game.CurrentPlayer.Name,
+			Credits:    game.CurrentPlayer.Credits,
+			Ship:       a.enrichShipData(ship),
+		},
+	}
 }
 
 // -----------------------------------------------------------------------------
@@ -259,10 +478,56 @@ func (a *App) GetAvailableContracts() []game.Contract {
 	return game.AvailableContracts[ship.LocationKey]
 }
 
+// GetMarketHistory returns up to 'window' recent market samples for a commodity
+// at a planet, oldest first. A window of 0 returns the full history kept.
+func (a *App) GetMarketHistory(planetKey, commodityKey string, window int) []game.MarketSample {
+	game.DataLock.RLock()
+	defer game.DataLock.RUnlock()
+
+	return game.GetMarketHistory(planetKey, commodityKey, window)
+}
+
+// GetMarketEvents returns the booms, strikes and blockades currently running.
+func (a *App) GetMarketEvents() []game.MarketEvent {
+	game.DataLock.RLock()
+	defer game.DataLock.RUnlock()
+
+	return game.GetActiveMarketEvents()
+}
+
+// GetNews returns sector headlines newer than sinceID (0 = whole feed).
+func (a *App) GetNews(sinceID int64) []game.NewsItem {
+	game.DataLock.RLock()
+	defer game.DataLock.RUnlock()
+
+	return game.GetNews(sinceID)
+}
+
+// GetNPCTraders returns the AI haulers working the sector, for the star map.
+func (a *App) GetNPCTraders() []*game.NPCTrader {
+	game.DataLock.RLock()
+	defer game.DataLock.RUnlock()
+
+	return game.GetNPCTraders()
+}
+
+// GetRivals returns the captains' standings table, player included.
+func (a *App) GetRivals() []game.RivalStanding {
+	game.DataLock.RLock()
+	defer game.DataLock.RUnlock()
+
+	return game.GetRivalStandings()
+}
+
 func (a *App) AcceptJob(contractID string) bool {
+	defer a.afterAction()
 	game.DataLock.Lock()
 	defer game.DataLock.Unlock()
 
+	if gameOver() || arrivalHeld() {
+		return false
+	}
+
 	ship := getActiveShip()
 	loc := ship.LocationKey
 	board := game.AvailableContracts[loc]
@@ -281,19 +546,7 @@ func (a *App) AcceptJob(contractID string) bool {
 		return false
 	}
 
-	currentCargo, currentPax := 0, 0
-	for _, c := range ship.ActiveContracts {
-		if c.Type == "cargo" {
-			currentCargo += c.Quantity
-		} else {
-			currentPax += c.Quantity
-		}
-	}
-
-	if target.Type == "cargo" && currentCargo+target.Quantity > ship.CargoCapacity {
-		return false
-	}
-	if target.Type == "passenger" && currentPax+target.Quantity > ship.PassengerSlots {
+	if !game.CanCarry(ship, target) {
 		return false
 	}
 
@@ -306,9 +559,14 @@ func (a *App) AcceptJob(contractID string) bool {
 }
 
 func (a *App) DropJob(contractID string) bool {
+	defer a.afterAction()
 	game.DataLock.Lock()
 	defer game.DataLock.Unlock()
 
+	if gameOver() || arrivalHeld() {
+		return false
+	}
+
 	ship := getActiveShip()
 
 	idx := -1
@@ -330,6 +588,55 @@ func (a *App) DropJob(contractID string) bool {
 	return true
 }
 
+// GetInsuranceQuotes prices cover for each contract aboard the active ship.
+func (a *App) GetInsuranceQuotes() []game.InsuranceQuote {
+	game.DataLock.RLock()
+	defer game.DataLock.RUnlock()
+	return game.GetInsuranceQuotes(getActiveShip())
+}
+
+// InsureContract buys cover for one contract aboard the active ship.
+func (a *App) InsureContract(contractID string) bool {
+	defer a.afterAction()
+	game.DataLock.Lock()
+	defer game.DataLock.Unlock()
+
+	if gameOver() {
+		return false
+	}
+
+	if contractID == "" {
+		return false
+	}
+	_, err := game.InsureContract(getActiveShip(), contractID)
+	return err == nil
+}
+
+// InsureTrip buys cover for every uninsured contract aboard the active ship.
+func (a *App) InsureTrip() bool {
+	defer a.afterAction()
+	game.DataLock.Lock()
+	defer game.DataLock.Unlock()
+
+	if gameOver() {
+		return false
+	}
+
+	_, err := game.InsureContract(getActiveShip(), "")
+	return err == nil
+}
+
+// GetInsuranceClaims lists insurance payouts received, oldest first.
+func (a *App) GetInsuranceClaims() []game.InsuranceClaim {
+	game.DataLock.RLock()
+	defer game.DataLock.RUnlock()
+
+	if game.CurrentPlayer.Claims == nil {
+		return []game.InsuranceClaim{}
+	}
+	return game.CurrentPlayer.Claims
+}
+
 // -----------------------------------------------------------------------------
 // MODULE & UPGRADE METHODS
 // -----------------------------------------------------------------------------
@@ -339,43 +646,416 @@ func (a *App) GetModules() []game.ShipModule {
 	defer game.DataLock.RUnlock()
 
 	ship := getActiveShip()
-	if ship.LocationKey != "planet_prime" {
+	return game.GetLocalModules(ship.LocationKey)
+}
+
+func (a *App) BuyModule(key string) bool {
+	defer a.afterAction()
+	game.DataLock.Lock()
+	defer game.DataLock.Unlock()
+
+	if gameOver() {
+		return false
+	}
+
+	return game.BuyModule(getActiveShip(), key) == nil
+}
+
+// UninstallModule moves an installed module from the active ship into storage.
+func (a *App) UninstallModule(index int) bool {
+	defer a.afterAction()
+	game.DataLock.Lock()
+	defer game.DataLock.Unlock()
+
+	if gameOver() {
+		return false
+	}
+
+	return game.UninstallModule(getActiveShip(), index) == nil
+}
+
+// InstallStoredModule refits a stored module onto the active ship.
+func (a *App) InstallStoredModule(storageIndex int) bool {
+	defer a.afterAction()
+	game.DataLock.Lock()
+	defer game.DataLock.Unlock()
+
+	if gameOver() {
+		return false
+	}
+
+	return game.InstallStoredModule(getActiveShip(), storageIndex) == nil
+}
+
+// SellModule sells a stored module to the local outfitter at resale value.
+func (a *App) SellModule(storageIndex int) bool {
+	defer a.afterAction()
+	game.DataLock.Lock()
+	defer game.DataLock.Unlock()
+
+	if gameOver() {
+		return false
+	}
+
+	_, err := game.SellStoredModule(getActiveShip(), storageIndex)
+	return err == nil
+}
+
+// GetModuleStorage lists uninstalled modules the player still owns.
+func (a *App) GetModuleStorage() []game.ShipModule {
+	game.DataLock.RLock()
+	defer game.DataLock.RUnlock()
+
+	if game.CurrentPlayer.ModuleStorage == nil {
 		return []game.ShipModule{}
 	}
-	return game.CurrentUniverse.ShipModules
+	return game.CurrentPlayer.ModuleStorage
 }
 
-func (a *App) BuyModule(key string) bool {
+// -----------------------------------------------------------------------------
+// HULL, REPAIR & MAINTENANCE METHODS
+// -----------------------------------------------------------------------------
+
+type RepairQuoteResponse struct {
+	Hull      int  `json:"hull"`
+	MaxHull   int  `json:"max_hull"`
+	Damage    int  `json:"damage"`
+	Cost      int  `json:"cost"` // Full repair at the local shipyard
+	Available bool `json:"available"`
+	CanAfford bool `json:"can_afford"`
+}
+
+// GetRepairQuote prices a full hull repair for the active ship where it is docked.
+func (a *App) GetRepairQuote() RepairQuoteResponse {
+	game.DataLock.RLock()
+	defer game.DataLock.RUnlock()
+
+	ship := getActiveShip()
+	damage := ship.MaxHull - ship.Hull
+	cost := game.RepairCost(ship, damage)
+	return RepairQuoteResponse{
+		Hull:      ship.Hull,
+		MaxHull:   ship.MaxHull,
+		Damage:    damage,
+		Cost:      cost,
+		Available: game.IsShipyard(ship.LocationKey),
+		CanAfford: game.CurrentPlayer.Credits >= cost,
+	}
+}
+
+// RepairShip repairs 'points' of hull on the active ship (0 = everything).
+func (a *App) RepairShip(points int) bool {
+	defer a.afterAction()
 	game.DataLock.Lock()
 	defer game.DataLock.Unlock()
 
+	if gameOver() {
+		return false
+	}
+
+	_, err := game.RepairHull(getActiveShip(), points)
+	return err == nil
+}
+
+type MaintenanceQuoteResponse struct {
+	EngineWear int64   `json:"engine_wear"` // LY since last service
+	BurnFactor float64 `json:"burn_factor"` // Current burn multiplier from wear
+	Cost       int     `json:"cost"`
+	Available  bool    `json:"available"`
+	CanAfford  bool    `json:"can_afford"`
+}
+
+// GetMaintenanceQuote prices an engine service for the active ship where it is docked.
+func (a *App) GetMaintenanceQuote() MaintenanceQuoteResponse {
+	game.DataLock.RLock()
+	defer game.DataLock.RUnlock()
+
 	ship := getActiveShip()
+	cost := game.MaintenanceCost(ship)
+	return MaintenanceQuoteResponse{
+		EngineWear: ship.EngineWear,
+		BurnFactor: game.EngineWearFactor(ship),
+		Cost:       cost,
+		Available:  game.OffersMaintenance(ship.LocationKey),
+		CanAfford:  game.CurrentPlayer.Credits >= cost,
+	}
+}
 
-	if ship.LocationKey != "planet_prime" {
+// ServiceEngine pays for an engine service on the active ship, resetting wear.
+func (a *App) ServiceEngine() bool {
+	defer a.afterAction()
+	game.DataLock.Lock()
+	defer game.DataLock.Unlock()
+
+	if gameOver() {
 		return false
 	}
-	if len(ship.InstalledModules) >= ship.MaxModuleSlots {
+
+	_, err := game.ServiceEngine(getActiveShip())
+	return err == nil
+}
+
+// -----------------------------------------------------------------------------
+// BANK & LOAN METHODS
+// -----------------------------------------------------------------------------
+
+type BankStatusResponse struct {
+	Available    bool        `json:"available"` // A bank is present where the active ship is docked
+	FleetValue   int         `json:"fleet_value"`
+	Collateral   int         `json:"collateral"` // What the bank lends against the fleet, before existing debt
+	CreditLimit  int         `json:"credit_limit"`
+	TotalOwed    int         `json:"total_owed"`
+	Debt         int         `json:"debt"`
+	InterestRate float64     `json:"interest_rate"` // Per tick, for new loans
+	TermTicks    int64       `json:"term_ticks"`
+	GameClock    int64       `json:"game_clock"`
+	Loans        []game.Loan `json:"loans"`
+}
+
+// GetBankStatus reports the player's borrowing position and loan history.
+func (a *App) GetBankStatus() BankStatusResponse {
+	game.DataLock.RLock()
+	defer game.DataLock.RUnlock()
+
+	loans := game.CurrentPlayer.Loans
+	if loans == nil {
+		loans = []game.Loan{}
+	}
+	return BankStatusResponse{
+		Available:    game.HasService(getActiveShip().LocationKey, game.ServiceBank),
+		FleetValue:   game.FleetValue(),
+		Collateral:   game.CollateralValue(),
+		CreditLimit:  game.CreditLimit(),
+		TotalOwed:    game.TotalOwed(),
+		Debt:         game.CurrentPlayer.Debt,
+		InterestRate: game.CurrentUniverse.BalanceConfig.LoanInterestRate,
+		TermTicks:    game.CurrentUniverse.BalanceConfig.LoanTermTicks,
+		GameClock:    game.GameClock,
+		Loans:        loans,
+	}
+}
+
+// TakeLoan borrows credits from the local bank against the fleet.
+func (a *App) TakeLoan(amount int) bool {
+	defer a.afterAction()
+	game.DataLock.Lock()
+	defer game.DataLock.Unlock()
+
+	if gameOver() {
 		return false
 	}
 
-	mod := game.GetModule(key)
-	if mod == nil || game.CurrentPlayer.Credits < mod.Cost {
+	_, err := game.TakeLoan(amount)
+	return err == nil
+}
+
+// RepayLoan pays down a loan from credits (0 = the full balance).
+func (a *App) RepayLoan(loanID string, amount int) bool {
+	defer a.afterAction()
+	game.DataLock.Lock()
+	defer game.DataLock.Unlock()
+
+	if gameOver() {
 		return false
 	}
 
-	game.CurrentPlayer.Credits -= mod.Cost
-	ship.InstalledModules = append(ship.InstalledModules, *mod)
+	_, err := game.RepayLoan(loanID, amount)
+	return err == nil
+}
+
+// -----------------------------------------------------------------------------
+// LEDGER & REPORT METHODS
+// -----------------------------------------------------------------------------
+
+// GetLedger lists credit movements matching the filter, oldest first.
+func (a *App) GetLedger(filter game.LedgerFilter) []game.LedgerEntry {
+	game.DataLock.RLock()
+	defer game.DataLock.RUnlock()
+	return game.GetLedger(filter)
+}
+
+// ExportLedgerCSV writes the filtered ledger to a timestamped CSV file
+// next to the save slots.
+func (a *App) ExportLedgerCSV(filter game.LedgerFilter) string {
+	filename := fmt.Sprintf("ledger_%s.csv", time.Now().Format("20060102_150405"))
+	if err := game.ExportLedgerCSV(filename, filter); err != nil {
+		return "EXPORT FAILED: " + err.Error()
+	}
+	return "LEDGER EXPORTED: " + filename
+}
+
+// GetReports returns profitability reports by route, ship, commodity and session.
+func (a *App) GetReports() game.Reports {
+	game.DataLock.RLock()
+	defer game.DataLock.RUnlock()
+	return game.GetReports()
+}
+
+// -----------------------------------------------------------------------------
+// FLEET & SHIPYARD METHODS
+// -----------------------------------------------------------------------------
 
-	switch mod.StatModifier {
-	case "cargo_capacity":
-		ship.CargoCapacity += mod.StatValue
-	case "passenger_slots":
-		ship.PassengerSlots += mod.StatValue
-	case "max_fuel":
-		ship.MaxFuel += int64(mod.StatValue)
-	case "base_burn_rate":
-		ship.BaseBurnRate += int64(mod.StatValue)
+// GetFleet returns every owned ship (active first) with computed mass and burn.
+func (a *App) GetFleet() []*game.Ship {
+	game.DataLock.RLock()
+	defer game.DataLock.RUnlock()
+
+	fleet := game.GetFleet()
+	for _, s := range fleet {
+		a.enrichShipData(s)
 	}
+	return fleet
+}
 
-	return true
+// GetShipyard lists the hulls for sale where the active ship is docked,
+// at local prices.
+func (a *App) GetShipyard() []game.ShipTemplate {
+	game.DataLock.RLock()
+	defer game.DataLock.RUnlock()
+
+	ship := getActiveShip()
+	return game.GetLocalShips(ship.LocationKey)
+}
+
+func (a *App) BuyShip(templateKey string, name string) bool {
+	defer a.afterAction()
+	game.DataLock.Lock()
+	defer game.DataLock.Unlock()
+
+	if gameOver() {
+		return false
+	}
+
+	_, err := game.BuyShip(templateKey, name)
+	return err == nil
+}
+
+func (a *App) SellShip(instanceID string) bool {
+	defer a.afterAction()
+	game.DataLock.Lock()
+	defer game.DataLock.Unlock()
+
+	if gameOver() {
+		return false
+	}
+
+	_, err := game.SellShip(instanceID)
+	return err == nil
+}
+
+func (a *App) RenameShip(instanceID string, name string) bool {
+	defer a.afterAction()
+	game.DataLock.Lock()
+	defer game.DataLock.Unlock()
+
+	if gameOver() {
+		return false
+	}
+
+	return game.RenameShip(instanceID, name) == nil
+}
+
+// TransferContracts hands contracts from one docked ship to another at the same planet.
+func (a *App) TransferContracts(fromID, toID string, contractIDs []string) bool {
+	defer a.afterAction()
+	game.DataLock.Lock()
+	defer game.DataLock.Unlock()
+
+	if gameOver() {
+		return false
+	}
+
+	return game.TransferContracts(fromID, toID, contractIDs) == nil
+}
+
+// TransferFuel pumps fuel between two ships docked at the same planet.
+func (a *App) TransferFuel(fromID, toID string, amount int64) bool {
+	defer a.afterAction()
+	game.DataLock.Lock()
+	defer game.DataLock.Unlock()
+
+	if gameOver() {
+		return false
+	}
+
+	return game.TransferFuel(fromID, toID, amount) == nil
+}
+
+// AssignRoute loops an idle fleet ship through 'stops', hauling contracts
+// worth at least minPayout between consecutive stops.
+func (a *App) AssignRoute(instanceID string, stops []string, minPayout int) bool {
+	defer a.afterAction()
+	game.DataLock.Lock()
+	defer game.DataLock.Unlock()
+
+	if gameOver() {
+		return false
+	}
+
+	return game.AssignRoute(instanceID, stops, minPayout) == nil
+}
+
+// AssignStandingOrder has an idle fleet ship haul anything from origin to
+// destination paying at least minPayout.
+func (a *App) AssignStandingOrder(instanceID, originKey, destinationKey string, minPayout int) bool {
+	defer a.afterAction()
+	game.DataLock.Lock()
+	defer game.DataLock.Unlock()
+
+	if gameOver() {
+		return false
+	}
+
+	return game.AssignStandingOrder(instanceID, originKey, destinationKey, minPayout) == nil
+}
+
+func (a *App) ClearOrders(instanceID string) bool {
+	defer a.afterAction()
+	game.DataLock.Lock()
+	defer game.DataLock.Unlock()
+
+	if gameOver() {
+		return false
+	}
+
+	return game.ClearOrders(instanceID) == nil
+}
+
+// GetTripLog returns completed trips for a ship, or the whole fleet if empty.
+func (a *App) GetTripLog(instanceID string) []game.TripRecord {
+	game.DataLock.RLock()
+	defer game.DataLock.RUnlock()
+
+	return game.GetTripLog(instanceID)
+}
+
+// SwitchShip makes another ship docked at the same planet the active one.
+func (a *App) SwitchShip(instanceID string) bool {
+	defer a.afterAction()
+	game.DataLock.Lock()
+	defer game.DataLock.Unlock()
+
+	if gameOver() {
+		return false
+	}
+
+	return game.SwitchActiveShip(instanceID) == nil
+}
+
+// -----------------------------------------------------------------------------
+// STATS & ACHIEVEMENT METHODS
+// -----------------------------------------------------------------------------
+
+// GetLifetimeStats returns the player's lifetime statistics.
+func (a *App) GetLifetimeStats() game.LifetimeStats {
+	game.DataLock.RLock()
+	defer game.DataLock.RUnlock()
+	return game.CurrentPlayer.Stats
+}
+
+// GetAchievements lists every achievement with unlock state and progress.
+func (a *App) GetAchievements() []game.AchievementStatus {
+	game.DataLock.RLock()
+	defer game.DataLock.RUnlock()
+	return game.GetAchievements()
 }
@@ -0,0 +1,178 @@
+/*
+Package game
+File: achievements.go
+Description:
+    Lifetime statistics and achievements.
+    Travel, deliveries, incidents and credit changes update the player's
+    LifetimeStats. Achievements are defined in universe.yaml as sets of
+    stat thresholds; EvaluateAchievements unlocks any newly met ones and is
+    run by the app after each action and heartbeat.
+*/
+
+package game
+
+import (
+	"fmt"
+	"time"
+)
+
+// GetAchievement returns the achievement definition by key.
+func GetAchievement(key string) *Achievement {
+	for i := range CurrentUniverse.Achievements {
+		if CurrentUniverse.Achievements[i].Key == key {
+			return &CurrentUniverse.Achievements[i]
+		}
+	}
+	return nil
+}
+
+// statValue looks up a lifetime (or live) stat by its universe.yaml name.
+// Unknown stats read as zero, so a typo never unlocks anything.
+func statValue(stat string) float64 {
+	st := &CurrentPlayer.Stats
+	switch stat {
+	case "distance_travelled":
+		return float64(st.DistanceTravelled)
+	case "trips":
+		return float64(st.Trips)
+	case "contracts_completed":
+		total := 0
+		for _, n := range st.ContractsCompleted {
+			total += n
+		}
+		return float64(total)
+	case "cargo_contracts":
+		return float64(st.ContractsCompleted["cargo"])
+	case "passenger_contracts":
+		return float64(st.ContractsCompleted["passenger"])
+	case "cargo_delivered":
+		return float64(st.CargoDelivered)
+	case "passengers_carried":
+		return float64(st.PassengersCarried)
+	case "credits_earned":
+		return float64(st.CreditsEarned)
+	case "fuel_burned":
+		return float64(st.FuelBurned) / 100 // Whole units
+	case "incidents_survived":
+		return float64(st.IncidentsSurvived)
+	case "peak_credits":
+		return float64(st.PeakCredits)
+	case "fleet_size":
+		return float64(len(CurrentPlayer.Ships))
+	}
+	return 0
+}
+
+// trackTrip adds a completed journey to the lifetime stats.
+// Note: Caller must hold DataLock
+func trackTrip(dist, fuelBurned int64) {
+	CurrentPlayer.Stats.Trips++
+	CurrentPlayer.Stats.DistanceTravelled += dist
+	CurrentPlayer.Stats.FuelBurned += fuelBurned
+}
+
+// trackDelivery adds a completed contract to the lifetime stats.
+// Note: Caller must hold DataLock
+func trackDelivery(c Contract) {
+	st := &CurrentPlayer.Stats
+	if st.ContractsCompleted == nil {
+		st.ContractsCompleted = map[string]int{}
+	}
+	st.ContractsCompleted[c.Type]++
+	st.CreditsEarned += c.Payout
+	if c.Type == "passenger" {
+		st.PassengersCarried += c.Quantity
+	} else {
+		st.CargoDelivered += c.Quantity
+	}
+}
+
+// trackIncidents counts travel incidents the ship came through.
+// Note: Caller must hold DataLock
+func trackIncidents(n int) {
+	CurrentPlayer.Stats.IncidentsSurvived += n
+}
+
+// trackCredits keeps the peak balance up to date.
+// Note: Caller must hold DataLock
+func trackCredits() {
+	if CurrentPlayer.Credits > CurrentPlayer.Stats.PeakCredits {
+		CurrentPlayer.Stats.PeakCredits = CurrentPlayer.Credits
+	}
+}
+
+// hasAchievement reports whether the player already unlocked the key.
+func hasAchievement(key string) bool {
+	for _, a := range CurrentPlayer.Achievements {
+		if a.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// EvaluateAchievements unlocks every achievement whose conditions are now
+// met. Returns the newly unlocked definitions.
+func EvaluateAchievements() []Achievement {
+	DataLock.Lock()
+	defer DataLock.Unlock()
+
+	unlocked := []Achievement{}
+	for _, a := range CurrentUniverse.Achievements {
+		if len(a.Conditions) == 0 || hasAchievement(a.Key) {
+			continue
+		}
+
+		met := true
+		for _, c := range a.Conditions {
+			if statValue(c.Stat) < c.Min {
+				met = false
+				break
+			}
+		}
+		if !met {
+			continue
+		}
+
+		CurrentPlayer.Achievements = append(CurrentPlayer.Achievements, UnlockedAchievement{
+			Key:       a.Key,
+			Tick:      GameClock,
+			Timestamp: time.Now(),
+		})
+		PublishNews("player", "", fmt.Sprintf("%s earns the \"%s\" distinction.", CurrentPlayer.Name, a.Name))
+		unlocked = append(unlocked, a)
+	}
+	return unlocked
+}
+
+// AchievementStatus is an achievement definition with the player's progress.
+type AchievementStatus struct {
+	Achievement
+	Unlocked   bool      `json:"unlocked"`
+	UnlockedAt time.Time `json:"unlocked_at"`
+	Progress   float64   `json:"progress"` // 0.0 - 1.0 towards the least-met condition
+}
+
+// GetAchievements lists every achievement with unlock state and progress.
+// Note: Caller must hold DataLock
+func GetAchievements() []AchievementStatus {
+	list := []AchievementStatus{}
+	for _, a := range CurrentUniverse.Achievements {
+		status := AchievementStatus{Achievement: a, Progress: 1}
+		for _, u := range CurrentPlayer.Achievements {
+			if u.Key == a.Key {
+				status.Unlocked = true
+				status.UnlockedAt = u.Timestamp
+			}
+		}
+		if !status.Unlocked {
+			for _, c := range a.Conditions {
+				if c.Min > 0 {
+					status.Progress = min(status.Progress, statValue(c.Stat)/c.Min)
+				}
+			}
+		}
+		list = append(list, status)
+	}
+	return list
+}
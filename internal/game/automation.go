@@ -0,0 +1,318 @@
+/*
+Package game
+File: automation.go
+Description:
+    Runs the player's non-active fleet ships on standing orders.
+    This includes:
+    1. Assigning looped routes or origin->destination standing orders.
+    2. Executing them on the economy heartbeat: buying fuel, accepting
+       contracts, flying on the game clock and delivering on arrival.
+    3. The trip log, which records every completed journey by a player ship
+       (automated or flown by the captain) with its fuel spend and earnings.
+*/
+
+package game
+
+import (
+	"errors"
+	"time"
+)
+
+// maxTripRecords caps how many trips the log (and the save file) keeps.
+const maxTripRecords = 500
+
+// AssignRoute puts an idle fleet ship on a looped route through 'stops'.
+// At each stop it accepts contracts bound for the next stop paying at least minPayout.
+// Note: Caller must hold DataLock
+func AssignRoute(shipID string, stops []string, minPayout int) error {
+	ship, err := automatableShip(shipID)
+	if err != nil {
+		return err
+	}
+	if len(stops) < 2 {
+		return errors.New("a route needs at least two stops")
+	}
+	for i, key := range stops {
+		if GetPlanet(key) == nil {
+			return errors.New("unknown planet: " + key)
+		}
+		if key == stops[(i+1)%len(stops)] {
+			return errors.New("consecutive stops must differ")
+		}
+	}
+
+	ship.Orders = &ShipOrders{
+		Mode:      "route",
+		Stops:     append([]string{}, stops...),
+		MinPayout: minPayout,
+	}
+	return nil
+}
+
+// AssignStandingOrder tells an idle fleet ship to haul any contract from
+// originKey to destKey paying at least minPayout, returning empty between runs.
+// Note: Caller must hold DataLock
+func AssignStandingOrder(shipID, originKey, destKey string, minPayout int) error {
+	ship, err := automatableShip(shipID)
+	if err != nil {
+		return err
+	}
+	if GetPlanet(originKey) == nil || GetPlanet(destKey) == nil {
+		return errors.New("unknown planet")
+	}
+	if originKey == destKey {
+		return errors.New("origin and destination must differ")
+	}
+
+	ship.Orders = &ShipOrders{
+		Mode:           "standing",
+		OriginKey:      originKey,
+		DestinationKey: destKey,
+		MinPayout:      minPayout,
+	}
+	return nil
+}
+
+// ClearOrders cancels automation for a ship. A ship already in flight
+// finishes its current leg and then stays docked at the destination.
+// Note: Caller must hold DataLock
+func ClearOrders(shipID string) error {
+	ship := CurrentPlayer.Ships[shipID]
+	if ship == nil {
+		return errors.New("unknown ship")
+	}
+	ship.Orders = nil
+	return nil
+}
+
+// automatableShip returns an owned ship that is allowed to take orders.
+func automatableShip(shipID string) (*Ship, error) {
+	ship := CurrentPlayer.Ships[shipID]
+	if ship == nil {
+		return nil, errors.New("unknown ship")
+	}
+	if shipID == CurrentPlayer.ActiveShipKey {
+		return nil, errors.New("the active ship is flown by the captain")
+	}
+	return ship, nil
+}
+
+// RunFleetOrders advances every automated fleet ship by one heartbeat.
+// Returns the planet keys whose job boards changed.
+func RunFleetOrders() []string {
+	DataLock.Lock()
+	defer DataLock.Unlock()
+
+	updated := []string{}
+	if CurrentPlayer.Bankrupt {
+		return updated // Game over: the fleet stands down
+	}
+	for _, ship := range GetFleet() {
+		if ship.InstanceID == CurrentPlayer.ActiveShipKey {
+			continue
+		}
+
+		// 1. In flight: arrive once the clock catches up
+		if ship.Voyage != nil {
+			if GameClock < ship.Voyage.ArrivalTick {
+				continue
+			}
+			arriveFleetShip(ship)
+		}
+
+		// 2. Docked with orders: load up and depart
+		if ship.Orders != nil {
+			if loc := ship.LocationKey; dispatchFleetShip(ship) {
+				updated = appendUnique(updated, loc)
+			}
+		}
+	}
+	return updated
+}
+
+// arriveFleetShip docks an automated ship, delivers and logs the trip.
+// Note: Caller must hold DataLock
+func arriveFleetShip(ship *Ship) {
+	voyage := ship.Voyage
+	ship.Voyage = nil
+	ship.LocationKey = voyage.DestinationKey
+
+	payout, delivered := DeliverContracts(ship)
+	RecordTrip(ship, voyage.OriginKey, voyage.DestinationKey, voyage.Distance, voyage.FuelBurned, delivered, payout, true)
+}
+
+// dispatchFleetShip follows the ship's orders from its current planet.
+// Returns true if it took contracts from the board.
+// Note: Caller must hold DataLock
+func dispatchFleetShip(ship *Ship) bool {
+	orders := ship.Orders
+	origin := GetPlanet(ship.LocationKey)
+	if origin == nil {
+		return false
+	}
+
+	// 1. Work out where to go next and whether to pick up work here
+	var targetKey string
+	pickUp := true
+	switch orders.Mode {
+	case "route":
+		if len(orders.Stops) == 0 {
+			return false
+		}
+		orders.NextStop %= len(orders.Stops)
+		if orders.Stops[orders.NextStop] == ship.LocationKey {
+			orders.NextStop = (orders.NextStop + 1) % len(orders.Stops)
+		}
+		targetKey = orders.Stops[orders.NextStop]
+	case "standing":
+		if ship.LocationKey == orders.OriginKey {
+			targetKey = orders.DestinationKey
+		} else {
+			targetKey = orders.OriginKey // Deadhead back to the pickup point
+			pickUp = false
+		}
+	default:
+		return false
+	}
+
+	dest := GetPlanet(targetKey)
+	if dest == nil {
+		return false
+	}
+
+	// 2. Accept every qualifying contract that fits
+	board := AvailableContracts[origin.Key]
+	taken := map[int]bool{}
+	if pickUp {
+		for i, c := range board {
+			if c.DestinationKey != dest.Key || c.Payout < orders.MinPayout || !CanCarry(ship, c) {
+				continue
+			}
+			ship.ActiveContracts = append(ship.ActiveContracts, c)
+			taken[i] = true
+		}
+	}
+
+	// Standing orders wait at the origin for work rather than fly empty.
+	if orders.Mode == "standing" && pickUp && len(taken) == 0 {
+		return false
+	}
+
+	// 3. Buy fuel with the captain's credits and check the leg is flyable
+	refuelFleetShip(ship)
+	dist := CalculateDistance(origin.Coordinates, dest.Coordinates)
+	cost := dist * CalculateCurrentBurn(ship)
+	if ship.Fuel < cost {
+		ship.ActiveContracts = ship.ActiveContracts[:len(ship.ActiveContracts)-len(taken)]
+		return false
+	}
+
+	// 4. Commit: take the contracts and launch
+	if len(taken) > 0 {
+		remaining := []Contract{}
+		for i, c := range board {
+			if taken[i] {
+				Market.RecordAcceptance(c.OriginKey, c.ItemKey, c.Quantity)
+				continue
+			}
+			remaining = append(remaining, c)
+		}
+		AvailableContracts[origin.Key] = remaining
+	}
+
+	ship.Fuel -= cost
+	AddEngineWear(ship, dist)
+	ship.Voyage = &Voyage{
+		OriginKey:      origin.Key,
+		DestinationKey: dest.Key,
+		ArrivalTick:    GameClock + TravelTicks(dist),
+		Distance:       dist,
+		FuelBurned:     cost,
+	}
+	return len(taken) > 0
+}
+
+// refuelFleetShip tops up an automated ship as far as the player's credits allow.
+// Note: Caller must hold DataLock
+func refuelFleetShip(ship *Ship) {
+	needed := AffordableFuel(ship.LocationKey, CurrentPlayer.Credits, ship.MaxFuel-ship.Fuel)
+	if needed <= 0 {
+		return
+	}
+
+	Transact(LedgerFuel, -FuelCost(ship.LocationKey, needed), shipRef(ship, "Automated refuel"))
+	ship.Fuel += needed
+}
+
+// DeliverContracts completes every contract bound for the ship's planet,
+// paying each one out and garnishing any debt. Returns the total payout
+// and the number of contracts delivered.
+// Note: Caller must hold DataLock
+func DeliverContracts(ship *Ship) (int, int) {
+	payout, delivered := 0, 0
+	remaining := []Contract{}
+	for _, c := range ship.ActiveContracts {
+		if c.DestinationKey != ship.LocationKey {
+			remaining = append(remaining, c)
+			continue
+		}
+		payout += c.Payout
+		delivered++
+		Market.RecordDelivery(c.DestinationKey, c.ItemKey, c.Quantity)
+		Transact(LedgerPayout, c.Payout, contractRef(ship, c, "Delivered "+c.ItemName))
+		trackDelivery(c)
+	}
+	ship.ActiveContracts = remaining
+
+	GarnishPayout(ship, payout)
+	return payout, delivered
+}
+
+// CompleteArrival delivers the captain's contracts at the ship's planet,
+// files any news and logs the trip. Travel calls it on landing, or
+// ResolveEvent once a decision raised on landing has been made.
+// Returns the payout.
+// Note: Caller must hold DataLock
+func CompleteArrival(ship *Ship, originKey string, dist, fuelBurned int64) int {
+	creditsBefore := CurrentPlayer.Credits
+	payout, delivered := DeliverContracts(ship)
+	ReportPlayerArrival(ship.LocationKey, payout, creditsBefore)
+	RecordTrip(ship, originKey, ship.LocationKey, dist, fuelBurned, delivered, payout, false)
+	return payout
+}
+
+// RecordTrip appends a completed journey to the trip log and lifetime stats.
+// Note: Caller must hold DataLock
+func RecordTrip(ship *Ship, originKey, destKey string, dist, fuelBurned int64, delivered, payout int, automated bool) {
+	trackTrip(dist, fuelBurned)
+	TripLog = append(TripLog, TripRecord{
+		Tick:           GameClock,
+		Timestamp:      time.Now(),
+		ShipID:         ship.InstanceID,
+		ShipName:       ship.Name,
+		OriginKey:      originKey,
+		DestinationKey: destKey,
+		Distance:       dist,
+		FuelBurned:     fuelBurned,
+		FuelCost:       FuelCost(originKey, fuelBurned),
+		Delivered:      delivered,
+		Payout:         payout,
+		Automated:      automated,
+	})
+	if len(TripLog) > maxTripRecords {
+		TripLog = TripLog[len(TripLog)-maxTripRecords:]
+	}
+}
+
+// GetTripLog returns logged trips for one ship, or for the whole fleet
+// when shipID is empty. Oldest first.
+// Note: Caller must hold DataLock
+func GetTripLog(shipID string) []TripRecord {
+	trips := []TripRecord{}
+	for _, t := range TripLog {
+		if shipID == "" || t.ShipID == shipID {
+			trips = append(trips, t)
+		}
+	}
+	return trips
+}
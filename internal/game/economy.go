@@ -4,9 +4,13 @@ File: economy.go
 Description:
     Handles the economic simulation of the universe.
     This includes:
-    1. Managing "Market Heat" (Supply/Demand fluctuations).
+    1. Managing "Market Heat" (Supply/Demand fluctuations) and its
+       diffusion between neighbouring planets.
     2. Replenishing job boards based on planet configuration.
     3. Generating procedural contracts (Cargo and Passengers).
+
+    Manufacturing (recipes and stockpiles) lives in production.go and
+    planet-level economic events live in market_events.go.
 */
 
 package game
@@ -18,7 +22,7 @@ import (
 	"time"
 )
 
-// InitMarket prepares the Market heat maps.
+// InitMarket prepares the Market heat maps and production stockpiles.
 // Sets all Source and Destination heat values to 1.0 (Neutral).
 func InitMarket() {
 	for _, p := range CurrentUniverse.Planets {
@@ -30,6 +34,8 @@ func InitMarket() {
 			Market.DestHeat[p.Key][c.Key] = 1.0
 		}
 	}
+
+	initStockpile()
 }
 
 // RecordAcceptance is called when a player takes a job.
@@ -54,10 +60,15 @@ func (m *MarketState) RecordDelivery(destKey, itemKey string, qty int) {
 	// Impact: 0.02 Heat per unit delivered (Markets crash faster than they recover).
 	impact := float64(qty) * 0.02
 	m.DestHeat[destKey][itemKey] += impact
+
+	// Factories keep what they can use as production input.
+	if p := GetPlanet(destKey); p != nil && IsRecipeInput(p, itemKey) {
+		m.addStock(destKey, itemKey, qty)
+	}
 }
 
 // MarketTick "Cools down" the economy, simulating consumption and production over time.
-// It pushes all heat values slowly back towards 1.0.
+// It pushes all heat values slowly back towards 1.0 and runs planet recipes.
 func MarketTick() {
 	DataLock.Lock()
 	defer DataLock.Unlock()
@@ -85,6 +96,73 @@ func MarketTick() {
 			}
 		}
 	}
+
+	// 3. Hold Shocks (Active market events resist recovery)
+	applyMarketEventHeat()
+
+	// 4. Spread Heat (Neighbouring markets feel each other's shocks)
+	diffuseHeat(Market.SourceHeat)
+	diffuseHeat(Market.DestHeat)
+
+	// 5. Run Factories (Delivered inputs become manufactured stock)
+	runProduction()
+
+	// 6. Advance the clock and record the settled market
+	GameClock++
+	recordMarketHistory()
+	reportHeatSwings()
+}
+
+// diffuseHeat moves each planet's heat deviation towards its neighbours.
+// Planets within HeatDiffusionRange exchange a share of the difference,
+// weighted linearly by distance (adjacent = full rate, edge of range = none).
+// All transfers are computed from a snapshot so planet order does not matter.
+// Note: Caller must hold DataLock
+func diffuseHeat(heat map[string]map[string]float64) {
+	rate := CurrentUniverse.BalanceConfig.HeatDiffusionRate
+	reach := CurrentUniverse.BalanceConfig.HeatDiffusionRange
+	if rate <= 0 || reach <= 0 {
+		return
+	}
+
+	// 1. Snapshot current values
+	snapshot := make(map[string]map[string]float64, len(heat))
+	for pKey, commodities := range heat {
+		snapshot[pKey] = make(map[string]float64, len(commodities))
+		for cKey, h := range commodities {
+			snapshot[pKey][cKey] = h
+		}
+	}
+
+	// 2. Exchange heat between every pair of planets in range
+	planets := CurrentUniverse.Planets
+	for i := range planets {
+		a := planets[i].Key
+		if snapshot[a] == nil {
+			continue
+		}
+		for j := range planets {
+			b := planets[j].Key
+			if i == j || snapshot[b] == nil {
+				continue
+			}
+
+			dist := CalculateDistance(planets[i].Coordinates, planets[j].Coordinates)
+			if dist >= reach {
+				continue
+			}
+			weight := rate * (1.0 - float64(dist)/float64(reach))
+
+			// Pull 'a' towards 'b'. The mirrored pass handles the reverse flow.
+			for cKey, hA := range snapshot[a] {
+				hB, ok := snapshot[b][cKey]
+				if !ok {
+					continue
+				}
+				heat[a][cKey] += (hB - hA) * weight
+			}
+		}
+	}
 }
 
 // ReplenishMarket is the main heartbeat function called by the server loop.
@@ -102,6 +180,11 @@ func ReplenishMarket() []string {
 	for i := range CurrentUniverse.Planets {
 		origin := &CurrentUniverse.Planets[i]
 
+		// Blockaded planets post no new work at all
+		if isBlockaded(origin.Key, "") {
+			continue
+		}
+
 		// Defaults if YAML is missing configuration
 		minCargo := origin.MinCargo
 		maxCargo := origin.MaxCargo
@@ -117,6 +200,14 @@ func ReplenishMarket() []string {
 			maxPax = 8
 		}
 
+		// Booms and strikes scale the size of the job board
+		if volume := marketEventVolume(origin.Key); volume != 1.0 {
+			minCargo = int(float64(minCargo) * volume)
+			maxCargo = int(float64(maxCargo) * volume)
+			minPax = int(float64(minPax) * volume)
+			maxPax = int(float64(maxPax) * volume)
+		}
+
 		// --- CARGO CHECK ---
 		currentCargoCount := 0
 		for _, c := range AvailableContracts[origin.Key] {
@@ -182,6 +273,9 @@ func generateCargoJobs(origin *Planet, count int) {
 		}
 
 		// 2. Scarcity Check: If Source Heat is too high, maybe fail to generate
+		if isBlockaded(origin.Key, comm.Key) {
+			continue
+		}
 		sourceHeat := Market.SourceHeat[origin.Key][comm.Key]
 		if sourceHeat > 1.0 && rand.Float64()*sourceHeat > 1.5 {
 			continue
@@ -195,29 +289,41 @@ func generateCargoJobs(origin *Planet, count int) {
 
 		// 4. Calculate Economics
 		qty := rand.Intn(21) + 5
-		dist := CalculateDistance(origin.Coordinates, dest.Coordinates)
-		destHeat := Market.DestHeat[dest.Key][comm.Key]
-		priceMod := 1.0 / destHeat // High saturation = Low Price
 
-		basePayout := int(dist)*CurrentUniverse.BalanceConfig.DistancePayoutMult + (comm.BaseValue * qty / 2)
-		finalPayout := int(float64(basePayout) * priceMod)
+		// Manufactured goods ship out of the local stockpile; a starved
+		// factory simply posts fewer jobs for them.
+		if IsManufactured(origin, comm.Key) && !Market.takeStock(origin.Key, comm.Key, qty) {
+			continue
+		}
 
 		// 5. Create Contract
-		job := Contract{
-			ID:             fmt.Sprintf("CRG-%d-%d", rand.Intn(99999), time.Now().UnixNano()%1000),
-			Type:           "cargo",
-			ItemName:       comm.Name,
-			ItemKey:        comm.Key,
-			Quantity:       qty,
-			MassPerUnit:    comm.Mass,
-			OriginKey:      origin.Key,
-			DestinationKey: dest.Key,
-			Payout:         finalPayout,
-		}
+		job := newCargoContract(origin, &dest, &comm, qty)
 		AvailableContracts[origin.Key] = append(AvailableContracts[origin.Key], job)
 	}
 }
 
+// newCargoContract prices a cargo job by distance and destination saturation.
+func newCargoContract(origin, dest *Planet, comm *Commodity, qty int) Contract {
+	dist := CalculateDistance(origin.Coordinates, dest.Coordinates)
+	destHeat := Market.DestHeat[dest.Key][comm.Key]
+	priceMod := 1.0 / destHeat // High saturation = Low Price
+
+	basePayout := int(dist)*CurrentUniverse.BalanceConfig.DistancePayoutMult + (comm.BaseValue * qty / 2)
+	finalPayout := int(float64(basePayout) * priceMod)
+
+	return Contract{
+		ID:             fmt.Sprintf("CRG-%d-%d", rand.Intn(99999), time.Now().UnixNano()%1000),
+		Type:           "cargo",
+		ItemName:       comm.Name,
+		ItemKey:        comm.Key,
+		Quantity:       qty,
+		MassPerUnit:    comm.Mass,
+		OriginKey:      origin.Key,
+		DestinationKey: dest.Key,
+		Payout:         finalPayout,
+	}
+}
+
 // generatePassengerJobs creates 'count' new passenger contracts.
 func generatePassengerJobs(origin *Planet, count int) {
 	for i := 0; i < count; i++ {
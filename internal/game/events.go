@@ -1,88 +1,671 @@
+/*
+Package game
+File: events.go
+Description:
+    Arrival events: random incidents and windfalls rolled when a player
+    ship lands.
+    Events are defined in universe.yaml (arrival_events). Each declares
+    conditions (contracts aboard, route, planets, hull condition), a
+    probability and a list of effects. This file is the generic engine that
+    checks, rolls and applies them.
+    Events with choices do not apply straight away: they become the player's
+    PendingEvent and the arrival is held (no deliveries) until ResolveEvent
+    applies a weighted random outcome of the option the captain picks and
+    completes it.
+    LoadConfig validates every definition (field names, effect types,
+    references and ranges) so a designer's typo fails loudly.
+*/
+
 package game
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"math"
 	"math/rand"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Arrival event effect types.
+const (
+	EffectFuelLoss       = "fuel_loss"
+	EffectRemoveContract = "remove_contract"
+	EffectCredits        = "credits"
+	EffectHullDamage     = "hull_damage"
+	EffectFuelGain       = "fuel_gain"
+	EffectSalvage        = "salvage"
+	EffectTip            = "tip"
+	EffectTipOff         = "tip_off"
 )
 
-// ProcessArrivalEvents calculates and applies random incidents based on RNG.
+// arrivalContext is what an event is evaluated against.
+type arrivalContext struct {
+	ship      *Ship
+	originKey string
+	dist      int64
+}
+
+// ProcessArrivalEvents rolls every arrival event defined in universe.yaml
+// for a ship that just flew from originKey (dist LY) to its current planet.
 // It directly mutates the passed Ship struct and returns a log of what happened.
-func ProcessArrivalEvents(ship *Ship) []TravelEvent {
-	var events []TravelEvent
+func ProcessArrivalEvents(ship *Ship, originKey string, dist int64) []TravelEvent {
+	ctx := arrivalContext{ship: ship, originKey: originKey, dist: dist}
 
-	// 1. FUEL LEAK CHECK (10% Chance)
-	// Mechanical failures are common in the prototyping phase.
-	if rand.Float64() < 0.10 {
-		// Lose between 5% and 15% of current fuel
-		lossPct := 0.05 + rand.Float64()*0.10
-		lossAmount := int64(float64(ship.Fuel) * lossPct)
+	events := []TravelEvent{}
+	incidents := 0 // Events that did harm; windfalls are not incidents
+	for i := range CurrentUniverse.ArrivalEvents {
+		def := &CurrentUniverse.ArrivalEvents[i]
+		if !ctx.eligible(def) {
+			continue
+		}
 
-		if lossAmount > 0 {
-			ship.Fuel -= lossAmount
-			events = append(events, TravelEvent{
-				Type:        "fuel_leak",
-				Description: "Micrometeoroid impact on fuel line! Emergency seal deployed.",
-				Effect:      fmt.Sprintf("Lost %d Fuel", lossAmount),
-			})
+		if len(def.Choices) > 0 && CurrentPlayer.PendingEvent != nil {
+			continue // One decision at a time
+		}
+
+		odds := def.Probability
+		if def.HullSensitive {
+			odds = HullEventOdds(ship, odds)
+		}
+		if rand.Float64() >= odds {
+			continue
 		}
-	}
 
-	// 2. CARGO LOSS CHECK (5% Chance)
-	// Requires active cargo contracts.
-	if rand.Float64() < 0.05 && len(ship.ActiveContracts) > 0 {
-		// Filter for cargo contracts
-		var cargoIndices []int
-		for i, c := range ship.ActiveContracts {
-			if c.Type == "cargo" {
-				cargoIndices = append(cargoIndices, i)
+		if len(def.Choices) > 0 {
+			ctx.raisePending(def)
+			continue
+		}
+		if ev, harmed, ok := ctx.apply(def); ok {
+			events = append(events, ev)
+			if harmed {
+				incidents++
 			}
 		}
+	}
+
+	trackIncidents(incidents)
+	return events
+}
 
-		if len(cargoIndices) > 0 {
-			// Pick a random contract to fail
-			targetIdx := cargoIndices[rand.Intn(len(cargoIndices))]
-			lostContract := ship.ActiveContracts[targetIdx]
+// eligible reports whether every condition of the event holds.
+func (ctx arrivalContext) eligible(def *ArrivalEventDef) bool {
+	c := def.Conditions
+	ship := ctx.ship
 
-			// Remove it from the ship (Slicing trick)
-			ship.ActiveContracts = append(
-				ship.ActiveContracts[:targetIdx],
-				ship.ActiveContracts[targetIdx+1:]...,
-			)
+	switch {
+	case c.MinDistance > 0 && ctx.dist < c.MinDistance:
+		return false
+	case c.MaxDistance > 0 && ctx.dist > c.MaxDistance:
+		return false
+	case len(c.Origins) > 0 && !containsKey(c.Origins, ctx.originKey):
+		return false
+	case len(c.Destinations) > 0 && !containsKey(c.Destinations, ship.LocationKey):
+		return false
+	case c.MaxHull > 0 && HullCondition(ship) > c.MaxHull:
+		return false
+	case c.MinHull > 0 && HullCondition(ship) < c.MinHull:
+		return false
+	}
 
-			events = append(events, TravelEvent{
-				Type:        "cargo_loss",
-				Description: fmt.Sprintf("Containment breach! %s jettisoned to prevent hull damage.", lostContract.ItemName),
-				Effect:      "Contract Failed (Item Lost)",
-			})
+	if c.ContractType != "" || len(c.Items) > 0 {
+		return len(ctx.matchingContracts(def)) > 0
+	}
+	return true
+}
+
+// matchingContracts returns the indices of contracts aboard that satisfy
+// the event's contract conditions.
+func (ctx arrivalContext) matchingContracts(def *ArrivalEventDef) []int {
+	c := def.Conditions
+	matches := []int{}
+	for i, ac := range ctx.ship.ActiveContracts {
+		if c.ContractType != "" && ac.Type != c.ContractType {
+			continue
+		}
+		if len(c.Items) > 0 && !containsKey(c.Items, ac.ItemKey) {
+			continue
 		}
+		if c.BoundHere && ac.DestinationKey != ctx.ship.LocationKey {
+			continue
+		}
+		matches = append(matches, i)
 	}
+	return matches
+}
+
+// apply carries out the event's effects, reporting whether any did harm.
+// Returns false if none had any effect (e.g. a fuel leak on an empty
+// tank), in which case nothing is reported.
+// Note: Caller must hold DataLock
+func (ctx arrivalContext) apply(def *ArrivalEventDef) (TravelEvent, bool, bool) {
+	summary, item, harmed := ctx.applyEffects(def, def.Effects, ctx.pickSubject(def))
+	if len(summary) == 0 {
+		return TravelEvent{}, false, false
+	}
+
+	effect := def.Effect
+	if effect == "" {
+		effect = strings.Join(summary, "; ")
+	}
+	return TravelEvent{
+		Type:        def.Type,
+		Description: ctx.describe(def.Description, item),
+		Effect:      effect,
+	}, harmed, true
+}
+
+// pickSubject chooses the contract an event is about, if it has contract
+// conditions. That contract names {item} and is the one remove_contract takes.
+// Returns -1 if there is none.
+func (ctx arrivalContext) pickSubject(def *ArrivalEventDef) int {
+	if def.Conditions.ContractType == "" && len(def.Conditions.Items) == 0 {
+		return -1
+	}
+	matches := ctx.matchingContracts(def)
+	if len(matches) == 0 {
+		return -1
+	}
+	return matches[rand.Intn(len(matches))]
+}
+
+// applyEffects runs a list of effects against the ship. Returns a summary
+// line per effect that did something, the item name for {item}, and whether
+// any of them did harm (lost fuel, hull, a contract or credits), which is
+// what counts as an incident survived.
+// Note: Caller must hold DataLock
+func (ctx arrivalContext) applyEffects(def *ArrivalEventDef, effects []ArrivalEffect, subject int) ([]string, string, bool) {
+	ship := ctx.ship
+	summary := []string{}
+	harmed := false
+
+	item := ""
+	if subject >= 0 {
+		item = ship.ActiveContracts[subject].ItemName
+	}
+
+	for _, eff := range effects {
+		switch eff.Type {
+		case EffectFuelLoss:
+			loss := int64(float64(ship.Fuel) * rollRange(eff))
+			if loss > 0 {
+				ship.Fuel -= loss
+				harmed = true
+				summary = append(summary, fmt.Sprintf("Lost %d Fuel", loss))
+			}
+
+		case EffectHullDamage:
+			if damage := DamageHull(ship, int(float64(ship.MaxHull)*rollRange(eff))); damage > 0 {
+				harmed = true
+				summary = append(summary, fmt.Sprintf("Lost %d Hull", damage))
+			}
+
+		case EffectCredits:
+			amount := int(math.Round(rollRange(eff)))
+			if amount < 0 && -amount > CurrentPlayer.Credits {
+				amount = -CurrentPlayer.Credits
+			}
+			if amount != 0 {
+				Transact(LedgerTravelEvent, amount, shipRef(ship, def.Type))
+				if amount > 0 {
+					summary = append(summary, fmt.Sprintf("Received %d Credits", amount))
+				} else {
+					harmed = true
+					summary = append(summary, fmt.Sprintf("Paid %d Credits", -amount))
+				}
+			}
+
+		case EffectFuelGain:
+			gain := int64(float64(ship.MaxFuel) * rollRange(eff))
+			if gain > ship.MaxFuel-ship.Fuel {
+				gain = ship.MaxFuel - ship.Fuel
+			}
+			if gain > 0 {
+				ship.Fuel += gain
+				summary = append(summary, fmt.Sprintf("Gained %d Fuel", gain))
+			}
 
-	// 3. PASSENGER INCIDENT CHECK (5% Chance)
-	// Requires active passenger contracts.
-	if rand.Float64() < 0.05 && len(ship.ActiveContracts) > 0 {
-		// Filter for passenger contracts
-		var paxIndices []int
-		for i, c := range ship.ActiveContracts {
-			if c.Type == "passenger" {
-				paxIndices = append(paxIndices, i)
+		case EffectTip:
+			// Only a passenger (or client) leaving the ship here tips
+			if subject < 0 || ship.ActiveContracts[subject].DestinationKey != ship.LocationKey {
+				continue
 			}
+			c := ship.ActiveContracts[subject]
+			if tip := int(float64(c.Payout) * rollRange(eff)); tip > 0 {
+				Transact(LedgerTravelEvent, tip, contractRef(ship, c, "Tip: "+def.Type))
+				summary = append(summary, fmt.Sprintf("Received %d Credit Tip", tip))
+			}
+
+		case EffectSalvage:
+			if job, ok := ctx.salvageContract(eff); ok {
+				ship.ActiveContracts = append(ship.ActiveContracts, job)
+				item = job.ItemName
+				summary = append(summary, fmt.Sprintf("Salvaged %d %s (deliver to %s)", job.Quantity, job.ItemName, planetName(job.DestinationKey)))
+			}
+
+		case EffectTipOff:
+			if job, ok := ctx.tipOffContract(eff); ok {
+				AvailableContracts[job.OriginKey] = append(AvailableContracts[job.OriginKey], job)
+				item = job.ItemName
+				summary = append(summary, fmt.Sprintf("New Contract: %s to %s for %d Credits", job.ItemName, planetName(job.DestinationKey), job.Payout))
+			}
+
+		case EffectRemoveContract:
+			if subject < 0 {
+				matches := ctx.matchingContracts(def)
+				if len(matches) == 0 {
+					continue
+				}
+				subject = matches[rand.Intn(len(matches))]
+			}
+			lost := ship.ActiveContracts[subject]
+			ship.ActiveContracts = append(ship.ActiveContracts[:subject], ship.ActiveContracts[subject+1:]...)
+			item = lost.ItemName
+			subject = -1
+			harmed = true
+
+			text := "Contract Failed (Item Lost)"
+			if lost.Type == "passenger" {
+				text = "Contract Voided"
+			}
+			if paid := settleClaim(ship, lost, def.Type); paid > 0 {
+				text = fmt.Sprintf("%s (Insurance paid %d)", text, paid)
+			}
+			summary = append(summary, text)
 		}
+	}
+	return summary, item, harmed
+}
 
-		if len(paxIndices) > 0 {
-			targetIdx := paxIndices[rand.Intn(len(paxIndices))]
-			// Remove it
-			ship.ActiveContracts = append(
-				ship.ActiveContracts[:targetIdx],
-				ship.ActiveContracts[targetIdx+1:]...,
-			)
+// salvageContract builds a free cargo job for goods found on the way in,
+// bound for another planet. Min/Max roll the quantity, trimmed to the
+// ship's free cargo space. Returns false if nothing fits.
+func (ctx arrivalContext) salvageContract(eff ArrivalEffect) (Contract, bool) {
+	here := GetPlanet(ctx.ship.LocationKey)
+	comm := pickEffectCommodity(eff)
+	dest := pickOtherPlanet(ctx.ship.LocationKey)
+	if here == nil || comm == nil || dest == nil {
+		return Contract{}, false
+	}
 
-			events = append(events, TravelEvent{
-				Type:        "passenger_loss",
-				Description: "Passenger demanded emergency disembark at a waypoint station.",
-				Effect:      "Contract Voided",
-			})
+	free := ctx.ship.CargoCapacity
+	for _, ac := range ctx.ship.ActiveContracts {
+		if ac.Type == "cargo" {
+			free -= ac.Quantity
 		}
 	}
+	qty := int(math.Round(rollRange(eff)))
+	if qty > free {
+		qty = free
+	}
+	if qty <= 0 {
+		return Contract{}, false
+	}
 
-	return events
+	job := newCargoContract(here, dest, comm, qty)
+	job.ID = "SLV" + strings.TrimPrefix(job.ID, "CRG")
+	return job, true
+}
+
+// tipOffContract builds a high-value job posted on the board at the ship's
+// new planet. MinQty/MaxQty roll the quantity and Min/Max the payout
+// multiplier. Manufactured goods come out of the local stockpile, as for
+// any other job; returns false if it runs short.
+func (ctx arrivalContext) tipOffContract(eff ArrivalEffect) (Contract, bool) {
+	here := GetPlanet(ctx.ship.LocationKey)
+	comm := pickEffectCommodity(eff)
+	dest := pickOtherPlanet(ctx.ship.LocationKey)
+	if here == nil || comm == nil || dest == nil {
+		return Contract{}, false
+	}
+
+	qty := eff.MinQty
+	if eff.MaxQty > eff.MinQty {
+		qty += rand.Intn(eff.MaxQty - eff.MinQty + 1)
+	}
+	if qty <= 0 {
+		return Contract{}, false
+	}
+	if IsManufactured(here, comm.Key) && !Market.takeStock(here.Key, comm.Key, qty) {
+		return Contract{}, false
+	}
+
+	job := newCargoContract(here, dest, comm, qty)
+	job.ID = "TIP" + strings.TrimPrefix(job.ID, "CRG")
+	job.Payout = int(float64(job.Payout) * math.Max(1, rollRange(eff)))
+	return job, true
+}
+
+// pickEffectCommodity picks one of an effect's items, or any commodity.
+func pickEffectCommodity(eff ArrivalEffect) *Commodity {
+	if len(eff.Items) > 0 {
+		return GetCommodity(eff.Items[rand.Intn(len(eff.Items))])
+	}
+	if len(CurrentUniverse.Commodities) == 0 {
+		return nil
+	}
+	return &CurrentUniverse.Commodities[rand.Intn(len(CurrentUniverse.Commodities))]
+}
+
+// pickOtherPlanet picks a random planet other than 'key'.
+func pickOtherPlanet(key string) *Planet {
+	others := []*Planet{}
+	for i := range CurrentUniverse.Planets {
+		if CurrentUniverse.Planets[i].Key != key {
+			others = append(others, &CurrentUniverse.Planets[i])
+		}
+	}
+	if len(others) == 0 {
+		return nil
+	}
+	return others[rand.Intn(len(others))]
+}
+
+// describe substitutes placeholders in an event description.
+func (ctx arrivalContext) describe(text, item string) string {
+	origin := planetName(ctx.originKey)
+	dest := planetName(ctx.ship.LocationKey)
+	return strings.NewReplacer("{item}", item, "{origin}", origin, "{destination}", dest).Replace(text)
+}
+
+// planetName returns a planet's display name, or the key if unknown.
+func planetName(key string) string {
+	if p := GetPlanet(key); p != nil {
+		return p.Name
+	}
+	return key
+}
+
+// rollRange picks a value uniformly between an effect's Min and Max.
+func rollRange(eff ArrivalEffect) float64 {
+	if eff.Max <= eff.Min {
+		return eff.Min
+	}
+	return eff.Min + rand.Float64()*(eff.Max-eff.Min)
+}
+
+// raisePending parks a player-choice event until the captain decides.
+// Note: Caller must hold DataLock
+func (ctx arrivalContext) raisePending(def *ArrivalEventDef) {
+	pending := &PendingEvent{
+		ID:        fmt.Sprintf("%s_%d", def.Key, GameClock),
+		Key:       def.Key,
+		Type:      def.Type,
+		ShipID:    ctx.ship.InstanceID,
+		OriginKey: ctx.originKey,
+		Distance:  ctx.dist,
+		Tick:      GameClock,
+	}
+
+	if subject := ctx.pickSubject(def); subject >= 0 {
+		pending.SubjectID = ctx.ship.ActiveContracts[subject].ID
+		pending.SubjectItem = ctx.ship.ActiveContracts[subject].ItemName
+	}
+	pending.Description = ctx.describe(def.Description, pending.SubjectItem)
+
+	for _, c := range def.Choices {
+		pending.Choices = append(pending.Choices, PendingChoice{ID: c.Key, Label: c.Label})
+	}
+	CurrentPlayer.PendingEvent = pending
+}
+
+// ResolveEvent applies the captain's choice to the pending event, then
+// completes the held arrival. One of the choice's outcomes is picked at
+// random, weighted by its Weight. Returns the event and the payout of the
+// contracts delivered on arrival.
+// Note: Caller must hold DataLock
+func ResolveEvent(eventID, choiceID string) (TravelEvent, int, error) {
+	pending := CurrentPlayer.PendingEvent
+	if pending == nil || pending.ID != eventID {
+		return TravelEvent{}, 0, errors.New("no such pending event")
+	}
+	ship := CurrentPlayer.Ships[pending.ShipID]
+	def := getArrivalEvent(pending.Key)
+	if ship == nil {
+		CurrentPlayer.PendingEvent = nil // No arrival left to complete
+		return TravelEvent{}, 0, errors.New("ship no longer in the fleet")
+	}
+	if def == nil {
+		// Removed from universe.yaml since: nothing to decide, just land
+		CurrentPlayer.PendingEvent = nil
+		event := TravelEvent{Type: pending.Type, Description: pending.Description, Effect: "No effect"}
+		return event, CompleteArrival(ship, pending.OriginKey, pending.Distance, pending.FuelBurned), nil
+	}
+	var choice *EventChoice
+	for i := range def.Choices {
+		if def.Choices[i].Key == choiceID {
+			choice = &def.Choices[i]
+			break
+		}
+	}
+	if choice == nil {
+		return TravelEvent{}, 0, errors.New("invalid choice")
+	}
+	CurrentPlayer.PendingEvent = nil
+
+	ctx := arrivalContext{ship: ship, originKey: pending.OriginKey, dist: pending.Distance}
+	subject := -1
+	for i, ac := range ship.ActiveContracts {
+		if pending.SubjectID != "" && ac.ID == pending.SubjectID {
+			subject = i
+			break
+		}
+	}
+
+	outcome := pickOutcome(choice.Outcomes)
+	summary, item, harmed := ctx.applyEffects(def, outcome.Effects, subject)
+	if item == "" {
+		item = pending.SubjectItem // Delivered since the event was raised
+	}
+
+	effect := strings.Join(summary, "; ")
+	if effect == "" {
+		effect = "No effect"
+	}
+	if harmed {
+		trackIncidents(1)
+	}
+	event := TravelEvent{
+		Type:        def.Type,
+		Description: ctx.describe(outcome.Description, item),
+		Effect:      effect,
+	}
+
+	payout := CompleteArrival(ship, pending.OriginKey, pending.Distance, pending.FuelBurned)
+	return event, payout, nil
+}
+
+// pickOutcome draws one outcome, weighted by Weight.
+func pickOutcome(outcomes []EventOutcome) EventOutcome {
+	total := 0.0
+	for _, o := range outcomes {
+		total += math.Max(0, o.Weight)
+	}
+	if total <= 0 {
+		if len(outcomes) == 0 {
+			return EventOutcome{}
+		}
+		return outcomes[0]
+	}
+
+	roll := rand.Float64() * total
+	for _, o := range outcomes {
+		roll -= math.Max(0, o.Weight)
+		if roll < 0 {
+			return o
+		}
+	}
+	return outcomes[len(outcomes)-1]
+}
+
+// getArrivalEvent finds an arrival event definition by key.
+func getArrivalEvent(key string) *ArrivalEventDef {
+	for i := range CurrentUniverse.ArrivalEvents {
+		if CurrentUniverse.ArrivalEvents[i].Key == key {
+			return &CurrentUniverse.ArrivalEvents[i]
+		}
+	}
+	return nil
+}
+
+// ValidateArrivalEvents checks the arrival_events section of universe.yaml.
+// 'data' is the raw file, used to reject misspelt field names, which the
+// normal decode silently ignores. Errors name the offending event key.
+// Note: Caller must hold DataLock
+func ValidateArrivalEvents(data []byte) error {
+	// 1. Field names: decode each event strictly
+	var raw struct {
+		ArrivalEvents []yaml.Node `yaml:"arrival_events"`
+	}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	for i := range raw.ArrivalEvents {
+		var probe struct {
+			Key string `yaml:"key"`
+		}
+		_ = raw.ArrivalEvents[i].Decode(&probe)
+
+		node, err := yaml.Marshal(&raw.ArrivalEvents[i])
+		if err != nil {
+			return fmt.Errorf("arrival event %q: %v", probe.Key, err)
+		}
+		dec := yaml.NewDecoder(bytes.NewReader(node))
+		dec.KnownFields(true)
+		var def ArrivalEventDef
+		if err := dec.Decode(&def); err != nil {
+			return fmt.Errorf("arrival event %q: %v", probe.Key, err)
+		}
+	}
+
+	// 2. Values and references
+	seen := map[string]bool{}
+	for i := range CurrentUniverse.ArrivalEvents {
+		def := &CurrentUniverse.ArrivalEvents[i]
+		if err := validateArrivalEvent(def, seen); err != nil {
+			return fmt.Errorf("arrival event %q: %v", def.Key, err)
+		}
+	}
+	return nil
+}
+
+// validateArrivalEvent checks one definition's values and references.
+func validateArrivalEvent(def *ArrivalEventDef, seen map[string]bool) error {
+	if def.Key == "" {
+		return errors.New("missing key")
+	}
+	if seen[def.Key] {
+		return errors.New("duplicate key")
+	}
+	seen[def.Key] = true
+
+	if def.Probability < 0 || def.Probability > 1 {
+		return fmt.Errorf("probability %v is outside 0-1", def.Probability)
+	}
+
+	c := def.Conditions
+	if c.ContractType != "" && c.ContractType != "cargo" && c.ContractType != "passenger" {
+		return fmt.Errorf("unknown contract_type %q", c.ContractType)
+	}
+	for _, item := range c.Items {
+		if item != "passenger" && GetCommodity(item) == nil {
+			return fmt.Errorf("unknown item %q in conditions", item)
+		}
+	}
+	for _, key := range append(append([]string{}, c.Origins...), c.Destinations...) {
+		if GetPlanet(key) == nil {
+			return fmt.Errorf("unknown planet %q in conditions", key)
+		}
+	}
+	if c.MinDistance < 0 || c.MaxDistance < 0 || (c.MaxDistance > 0 && c.MaxDistance < c.MinDistance) {
+		return errors.New("invalid min_distance/max_distance")
+	}
+	if c.MinHull < 0 || c.MaxHull < 0 || c.MinHull > 1 || c.MaxHull > 1 || (c.MaxHull > 0 && c.MaxHull < c.MinHull) {
+		return errors.New("invalid min_hull/max_hull (hull condition is 0.0 - 1.0)")
+	}
+
+	hasSubject := c.ContractType != "" || len(c.Items) > 0
+	if len(def.Choices) == 0 {
+		if len(def.Effects) == 0 {
+			return errors.New("no effects or choices")
+		}
+		return validateEffects(def.Effects, hasSubject)
+	}
+
+	if len(def.Effects) > 0 {
+		return errors.New("effects and choices cannot be combined; put effects in choice outcomes")
+	}
+	choices := map[string]bool{}
+	for _, choice := range def.Choices {
+		if choice.Key == "" || choice.Label == "" {
+			return errors.New("choice missing key or label")
+		}
+		if choices[choice.Key] {
+			return fmt.Errorf("duplicate choice %q", choice.Key)
+		}
+		choices[choice.Key] = true
+
+		if len(choice.Outcomes) == 0 {
+			return fmt.Errorf("choice %q has no outcomes", choice.Key)
+		}
+		total := 0.0
+		for _, o := range choice.Outcomes {
+			if o.Weight < 0 {
+				return fmt.Errorf("choice %q has a negative outcome weight", choice.Key)
+			}
+			total += o.Weight
+			if err := validateEffects(o.Effects, hasSubject); err != nil {
+				return fmt.Errorf("choice %q: %v", choice.Key, err)
+			}
+		}
+		if total <= 0 {
+			return fmt.Errorf("choice %q has no outcome with a positive weight", choice.Key)
+		}
+	}
+	return nil
+}
+
+// validateEffects checks effect types and their ranges.
+func validateEffects(effects []ArrivalEffect, hasSubject bool) error {
+	for _, eff := range effects {
+		if eff.Max != 0 && eff.Max < eff.Min {
+			return fmt.Errorf("%s: max is below min", eff.Type)
+		}
+		for _, item := range eff.Items {
+			if GetCommodity(item) == nil {
+				return fmt.Errorf("%s: unknown item %q", eff.Type, item)
+			}
+		}
+
+		switch eff.Type {
+		case EffectFuelLoss, EffectFuelGain, EffectHullDamage:
+			if eff.Min < 0 || eff.Max > 1 || eff.Min > 1 {
+				return fmt.Errorf("%s: share must be within 0-1", eff.Type)
+			}
+		case EffectTip:
+			if !hasSubject {
+				return errors.New("tip needs a contract_type or items condition")
+			}
+			if eff.Min < 0 {
+				return errors.New("tip: share cannot be negative")
+			}
+		case EffectSalvage:
+			if eff.Min < 0 {
+				return errors.New("salvage: quantity cannot be negative")
+			}
+		case EffectTipOff:
+			if eff.Min < 0 {
+				return errors.New("tip_off: multiplier cannot be negative")
+			}
+			if eff.MinQty <= 0 || (eff.MaxQty != 0 && eff.MaxQty < eff.MinQty) {
+				return errors.New("tip_off: needs min_qty > 0 and max_qty >= min_qty")
+			}
+		case EffectCredits, EffectRemoveContract:
+		default:
+			return fmt.Errorf("unknown effect type %q", eff.Type)
+		}
+	}
+	return nil
 }
@@ -0,0 +1,266 @@
+/*
+Package game
+File: fleet.go
+Description:
+    Fleet management for the player.
+    This includes buying new hulls at a shipyard, selling used ones at a
+    depreciated price, renaming ships, switching which ship is active, and
+    moving contracts or fuel between ships docked together.
+    All functions expect the caller (app.go) to hold DataLock.
+*/
+
+package game
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ShipResaleValue is what a shipyard pays for a used ship,
+// including the resale value of its installed modules.
+func ShipResaleValue(s *Ship) int {
+	template := GetShipTemplate(s.TemplateKey)
+	if template == nil {
+		return 0
+	}
+
+	value := int(float64(template.Cost) * CurrentUniverse.BalanceConfig.ShipResaleRate)
+	for i := range s.InstalledModules {
+		value += ModuleResaleValue(&s.InstalledModules[i])
+	}
+	return value
+}
+
+// BuyShip purchases a new ship from the shipyard where the active ship is docked.
+// The new ship joins the fleet docked alongside; it does not become active.
+// Note: Caller must hold DataLock
+func BuyShip(templateKey, name string) (*Ship, error) {
+	active := CurrentPlayer.Ships[CurrentPlayer.ActiveShipKey]
+	if active == nil || !IsShipyard(active.LocationKey) {
+		return nil, errors.New("no shipyard at this location")
+	}
+
+	template := GetShipTemplate(templateKey)
+	if template == nil || template.Cost <= 0 || !StocksShip(active.LocationKey, templateKey) {
+		return nil, errors.New("ship type not for sale here")
+	}
+	price := LocalPrice(active.LocationKey, template.Cost)
+	if CurrentPlayer.Credits < price {
+		return nil, errors.New("insufficient credits")
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = "SS " + template.Name
+	}
+
+	id := nextShipInstanceID()
+	ship := NewShipFromTemplate(template, id, name, active.LocationKey)
+
+	CurrentPlayer.Ships[id] = ship
+	Transact(LedgerShipPurchase, -price, shipRef(ship, "Bought "+template.Name))
+	return ship, nil
+}
+
+// SellShip sells a docked, empty ship back to the shipyard.
+// The active ship cannot be sold, and a ship under lien pays off the
+// player's debt from the sale first. Returns the credits kept.
+// Note: Caller must hold DataLock
+func SellShip(instanceID string) (int, error) {
+	ship := CurrentPlayer.Ships[instanceID]
+	if ship == nil {
+		return 0, errors.New("unknown ship")
+	}
+	if instanceID == CurrentPlayer.ActiveShipKey {
+		return 0, errors.New("cannot sell the active ship")
+	}
+	if ship.Voyage != nil {
+		return 0, errors.New("ship is in flight")
+	}
+	if !IsShipyard(ship.LocationKey) {
+		return 0, errors.New("ship is not docked at a shipyard")
+	}
+	if len(ship.ActiveContracts) > 0 {
+		return 0, errors.New("ship still has contracts aboard")
+	}
+
+	value := ShipResaleValue(ship)
+	Transact(LedgerShipSale, value, shipRef(ship, "Sold "+ship.Name))
+	if UnderLien(instanceID) && CurrentPlayer.Debt > 0 {
+		// The bank holding the lien is paid out of the sale first
+		paid := min(value, CurrentPlayer.Debt)
+		Transact(LedgerDebtRepayment, -paid, shipRef(ship, "Lien on "+ship.Name+" settled from sale"))
+		CurrentPlayer.Debt -= paid
+		value -= paid
+	}
+	delete(CurrentPlayer.Ships, instanceID)
+	return value, nil
+}
+
+// RenameShip changes the display name of an owned ship.
+// Note: Caller must hold DataLock
+func RenameShip(instanceID, name string) error {
+	ship := CurrentPlayer.Ships[instanceID]
+	if ship == nil {
+		return errors.New("unknown ship")
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return errors.New("name cannot be empty")
+	}
+	ship.Name = name
+	return nil
+}
+
+// SwitchActiveShip makes another owned ship the one the player flies.
+// The captain can only step across to a ship docked at the same planet.
+// Any standing orders on the new active ship are cancelled.
+// Note: Caller must hold DataLock
+func SwitchActiveShip(instanceID string) error {
+	target := CurrentPlayer.Ships[instanceID]
+	if target == nil {
+		return errors.New("unknown ship")
+	}
+	if target.Voyage != nil {
+		return errors.New("ship is in flight")
+	}
+	if CurrentPlayer.PendingEvent != nil {
+		return errors.New("resolve the pending event first")
+	}
+
+	active := CurrentPlayer.Ships[CurrentPlayer.ActiveShipKey]
+	if active != nil && active.LocationKey != target.LocationKey {
+		return errors.New("ship is not docked at your location")
+	}
+
+	target.Orders = nil
+	CurrentPlayer.ActiveShipKey = instanceID
+	return nil
+}
+
+// TransferContracts moves contracts between two ships docked at the same planet.
+// Either every listed contract fits in the receiving ship or nothing moves.
+// Refused while deliveries are held for a pending arrival event.
+// Note: Caller must hold DataLock
+func TransferContracts(fromID, toID string, contractIDs []string) error {
+	from, to, err := dockedPair(fromID, toID)
+	if err != nil {
+		return err
+	}
+	if CurrentPlayer.PendingEvent != nil {
+		return errors.New("resolve the pending event first")
+	}
+	if len(contractIDs) == 0 {
+		return errors.New("no contracts selected")
+	}
+
+	wanted := make(map[string]bool, len(contractIDs))
+	for _, id := range contractIDs {
+		wanted[id] = true
+	}
+
+	// 1. Split the sender's manifest, checking capacity as we load the receiver
+	moving := []Contract{}
+	staying := []Contract{}
+	trial := &Ship{
+		CargoCapacity:   to.CargoCapacity,
+		PassengerSlots:  to.PassengerSlots,
+		ActiveContracts: append([]Contract{}, to.ActiveContracts...),
+	}
+	for _, c := range from.ActiveContracts {
+		if !wanted[c.ID] {
+			staying = append(staying, c)
+			continue
+		}
+		if !CanCarry(trial, c) {
+			return errors.New("not enough room aboard for " + c.ItemName)
+		}
+		trial.ActiveContracts = append(trial.ActiveContracts, c)
+		moving = append(moving, c)
+	}
+	if len(moving) != len(wanted) {
+		return errors.New("contract not found aboard")
+	}
+
+	// 2. Commit
+	from.ActiveContracts = staying
+	to.ActiveContracts = append(to.ActiveContracts, moving...)
+	return nil
+}
+
+// TransferFuel pumps fuel (in hundredths of a unit) between two docked ships.
+// Note: Caller must hold DataLock
+func TransferFuel(fromID, toID string, amount int64) error {
+	from, to, err := dockedPair(fromID, toID)
+	if err != nil {
+		return err
+	}
+	if amount <= 0 {
+		return errors.New("amount must be positive")
+	}
+	if amount > from.Fuel {
+		return errors.New("not enough fuel to transfer")
+	}
+	if to.Fuel+amount > to.MaxFuel {
+		return errors.New("receiving tank cannot hold that much")
+	}
+
+	from.Fuel -= amount
+	to.Fuel += amount
+	return nil
+}
+
+// dockedPair returns two distinct owned ships docked at the same planet.
+func dockedPair(fromID, toID string) (*Ship, *Ship, error) {
+	from := CurrentPlayer.Ships[fromID]
+	to := CurrentPlayer.Ships[toID]
+	if from == nil || to == nil {
+		return nil, nil, errors.New("unknown ship")
+	}
+	if fromID == toID {
+		return nil, nil, errors.New("choose two different ships")
+	}
+	if from.Voyage != nil || to.Voyage != nil {
+		return nil, nil, errors.New("ship is in flight")
+	}
+	if from.LocationKey != to.LocationKey {
+		return nil, nil, errors.New("ships are not docked together")
+	}
+	return from, to, nil
+}
+
+// GetFleet returns every ship the player owns, active ship first,
+// the rest ordered by instance ID.
+// Note: Caller must hold DataLock
+func GetFleet() []*Ship {
+	others := []*Ship{}
+	for id, s := range CurrentPlayer.Ships {
+		if id != CurrentPlayer.ActiveShipKey {
+			others = append(others, s)
+		}
+	}
+	sort.Slice(others, func(i, j int) bool {
+		return others[i].InstanceID < others[j].InstanceID
+	})
+
+	fleet := []*Ship{}
+	if active := CurrentPlayer.Ships[CurrentPlayer.ActiveShipKey]; active != nil {
+		fleet = append(fleet, active)
+	}
+	return append(fleet, others...)
+}
+
+// nextShipInstanceID returns an unused "ship_N" key for the player's fleet.
+func nextShipInstanceID() string {
+	highest := 0
+	for id := range CurrentPlayer.Ships {
+		if n, err := strconv.Atoi(strings.TrimPrefix(id, "ship_")); err == nil && n > highest {
+			highest = n
+		}
+	}
+	return fmt.Sprintf("ship_%d", highest+1)
+}
@@ -0,0 +1,95 @@
+/*
+Package game
+File: fuel.go
+Description:
+    Local fuel prices and refuelling.
+    The list price (BalanceConfig.FuelCostPerUnit) is adjusted per planet by
+    its fuel_price_mult, a discount at fuel depots, and the local item_fuel
+    market: scarce fuel (high SourceHeat) costs more, a glut (high DestHeat)
+    costs less. Fuel is stored in hundredths of a unit throughout.
+*/
+
+package game
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+const fuelKey = "item_fuel"
+
+// LocalFuelPrice returns the price of 1.00 unit of fuel at a planet.
+func LocalFuelPrice(planetKey string) float64 {
+	cfg := CurrentUniverse.BalanceConfig
+	price := float64(cfg.FuelCostPerUnit)
+
+	p := GetPlanet(planetKey)
+	if p == nil {
+		return price
+	}
+	if p.FuelPriceMult > 0 {
+		price *= p.FuelPriceMult
+	}
+	if HasService(planetKey, ServiceFuelDepot) && cfg.FuelDepotDiscount > 0 {
+		price *= cfg.FuelDepotDiscount
+	}
+
+	// Local market: scarcity raises, saturation lowers the price
+	heat := 1.0
+	if src, ok := Market.SourceHeat[planetKey][fuelKey]; ok && src > 0 {
+		heat *= src
+	}
+	if dst, ok := Market.DestHeat[planetKey][fuelKey]; ok && dst > 0 {
+		heat /= dst
+	}
+	return price * math.Max(0.5, math.Min(2.5, heat))
+}
+
+// FuelCost returns the credit price of 'units' of fuel at a planet.
+// Rounds up so even small top-ups cost something.
+func FuelCost(planetKey string, units int64) int {
+	if units <= 0 {
+		return 0
+	}
+	return int(math.Ceil(float64(units)*LocalFuelPrice(planetKey)/100 - 1e-9))
+}
+
+// AffordableFuel returns how many of the 'wanted' units 'credits' can buy at a planet.
+func AffordableFuel(planetKey string, credits int, wanted int64) int64 {
+	price := LocalFuelPrice(planetKey)
+	if wanted <= 0 || credits <= 0 || price <= 0 {
+		return 0
+	}
+
+	units := int64(float64(credits) * 100 / price)
+	if units > wanted {
+		units = wanted
+	}
+	for units > 0 && FuelCost(planetKey, units) > credits {
+		units--
+	}
+	return units
+}
+
+// RefuelShip buys 'units' of fuel for a player ship (fills the tank if
+// units <= 0, capped at tank space). Returns the credits spent.
+// Note: Caller must hold DataLock
+func RefuelShip(s *Ship, units int64) (int, error) {
+	space := s.MaxFuel - s.Fuel
+	if space <= 0 {
+		return 0, errors.New("tank is already full")
+	}
+	if units <= 0 || units > space {
+		units = space
+	}
+
+	cost := FuelCost(s.LocationKey, units)
+	if CurrentPlayer.Credits < cost {
+		return 0, errors.New("insufficient credits")
+	}
+
+	Transact(LedgerFuel, -cost, shipRef(s, fmt.Sprintf("Refuelled %d.%02d units", units/100, units%100)))
+	s.Fuel += units
+	return cost, nil
+}
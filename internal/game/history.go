@@ -0,0 +1,106 @@
+/*
+Package game
+File: history.go
+Description:
+    Keeps a rolling record of the market so trends can be charted.
+    Every market tick stores one MarketSample per planet/commodity in a
+    fixed-size ring buffer (PriceHistory). The buffers are saved with the game.
+*/
+
+package game
+
+// defaultHistoryLength is used when universe.yaml omits market_history_length.
+const defaultHistoryLength = 240
+
+// push stores a sample, overwriting the oldest one once the buffer is full.
+func (h *PriceHistory) push(sample MarketSample, capacity int) {
+	if len(h.Samples) < capacity {
+		h.Samples = append(h.Samples, sample)
+		h.Next = len(h.Samples) % capacity
+		return
+	}
+
+	// Buffer shrank in config since this save was made: drop the oldest.
+	if len(h.Samples) > capacity {
+		h.Samples = h.Ordered(capacity)
+		h.Next = 0
+	}
+
+	h.Samples[h.Next] = sample
+	h.Next = (h.Next + 1) % capacity
+}
+
+// Ordered returns up to 'window' of the most recent samples, oldest first.
+// A window of 0 (or less) returns every sample held.
+func (h *PriceHistory) Ordered(window int) []MarketSample {
+	n := len(h.Samples)
+	ordered := make([]MarketSample, 0, n)
+
+	// Until the buffer wraps, Next points past the newest sample and the
+	// slice is already chronological. Afterwards, Next is the oldest sample.
+	start := 0
+	if n > 0 && h.Next < n {
+		start = h.Next
+	}
+	for i := 0; i < n; i++ {
+		ordered = append(ordered, h.Samples[(start+i)%n])
+	}
+
+	if window > 0 && window < len(ordered) {
+		ordered = ordered[len(ordered)-window:]
+	}
+	return ordered
+}
+
+// recordMarketHistory captures the current heat and payout of every commodity.
+// Note: Caller must hold DataLock
+func recordMarketHistory() {
+	if MarketHistory == nil {
+		MarketHistory = make(map[string]map[string]*PriceHistory)
+	}
+
+	capacity := CurrentUniverse.BalanceConfig.MarketHistoryLength
+	if capacity <= 0 {
+		capacity = defaultHistoryLength
+	}
+
+	for _, p := range CurrentUniverse.Planets {
+		if MarketHistory[p.Key] == nil {
+			MarketHistory[p.Key] = make(map[string]*PriceHistory)
+		}
+
+		for _, c := range CurrentUniverse.Commodities {
+			sourceHeat := Market.SourceHeat[p.Key][c.Key]
+			destHeat := Market.DestHeat[p.Key][c.Key]
+
+			// Same pricing rule as generateCargoJobs: saturation lowers payouts.
+			unitPayout := c.BaseValue
+			if destHeat > 0 {
+				unitPayout = int(float64(c.BaseValue) / destHeat)
+			}
+
+			series := MarketHistory[p.Key][c.Key]
+			if series == nil {
+				series = &PriceHistory{}
+				MarketHistory[p.Key][c.Key] = series
+			}
+			series.push(MarketSample{
+				Tick:       GameClock,
+				SourceHeat: sourceHeat,
+				DestHeat:   destHeat,
+				UnitPayout: unitPayout,
+			}, capacity)
+		}
+	}
+}
+
+// GetMarketHistory returns the recent samples for a commodity at a planet,
+// oldest first. Returns an empty slice if nothing has been recorded yet.
+// Note: Caller must hold DataLock
+func GetMarketHistory(planetKey, itemKey string, window int) []MarketSample {
+	series := MarketHistory[planetKey][itemKey]
+	if series == nil {
+		return []MarketSample{}
+	}
+	return series.Ordered(window)
+}
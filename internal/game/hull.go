@@ -0,0 +1,81 @@
+/*
+Package game
+File: hull.go
+Description:
+    Hull integrity for player ships.
+    Travel incidents damage the hull; a battered hull burns more fuel and
+    runs into trouble more often. Shipyards repair it, priced per point.
+*/
+
+package game
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// HullCondition returns the ship's hull as a share of its maximum (0.0 - 1.0).
+// Ships without hull tracking are always in perfect condition.
+func HullCondition(s *Ship) float64 {
+	if s.MaxHull <= 0 {
+		return 1.0
+	}
+	return math.Max(0, math.Min(1, float64(s.Hull)/float64(s.MaxHull)))
+}
+
+// HullBurnFactor is the burn multiplier caused by hull damage.
+func HullBurnFactor(s *Ship) float64 {
+	return 1.0 + CurrentUniverse.BalanceConfig.HullBurnPenalty*(1.0-HullCondition(s))
+}
+
+// HullEventOdds scales an incident's base chance by the ship's hull damage.
+func HullEventOdds(s *Ship, base float64) float64 {
+	return base * (1.0 + CurrentUniverse.BalanceConfig.HullEventPenalty*(1.0-HullCondition(s)))
+}
+
+// DamageHull knocks points off the hull. A ship always limps home with
+// at least one point left. Returns the damage actually taken.
+func DamageHull(s *Ship, points int) int {
+	if s.MaxHull <= 0 || points <= 0 {
+		return 0
+	}
+	if points > s.Hull-1 {
+		points = s.Hull - 1
+	}
+	if points < 0 {
+		points = 0
+	}
+	s.Hull -= points
+	return points
+}
+
+// RepairCost prices repairing 'points' of hull at the ship's current planet.
+func RepairCost(s *Ship, points int) int {
+	return LocalPrice(s.LocationKey, points*CurrentUniverse.BalanceConfig.HullRepairCost)
+}
+
+// RepairHull repairs up to 'points' of damage (all of it if points <= 0)
+// at a shipyard. Returns the credits spent.
+// Note: Caller must hold DataLock
+func RepairHull(s *Ship, points int) (int, error) {
+	if !IsShipyard(s.LocationKey) {
+		return 0, errors.New("no shipyard at this location")
+	}
+	damage := s.MaxHull - s.Hull
+	if damage <= 0 {
+		return 0, errors.New("hull is already at full strength")
+	}
+	if points <= 0 || points > damage {
+		points = damage
+	}
+
+	cost := RepairCost(s, points)
+	if CurrentPlayer.Credits < cost {
+		return 0, errors.New("insufficient credits")
+	}
+
+	Transact(LedgerRepair, -cost, shipRef(s, fmt.Sprintf("Repaired %d hull", points)))
+	s.Hull += points
+	return cost, nil
+}
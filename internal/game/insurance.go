@@ -0,0 +1,160 @@
+/*
+Package game
+File: insurance.go
+Description:
+    Cargo and passenger insurance.
+    Contracts aboard a docked ship can be insured before departure. The
+    premium is priced by the value of the goods (or the passenger's fare)
+    and the route risk: longer routes and battered hulls cost more. When
+    ProcessArrivalEvents loses an insured contract, the player is paid its
+    coverage, capped by the contract's payout, and a claim is recorded.
+    Cover cannot be bought while an arrival incident awaits a decision.
+*/
+
+package game
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// maxClaims caps how many claims the player's record (and the save file) keeps.
+const maxClaims = 200
+
+// InsuranceQuote prices cover for one contract aboard a ship.
+type InsuranceQuote struct {
+	ContractID string `json:"contract_id"`
+	ItemName   string `json:"item_name"`
+	Distance   int64  `json:"distance"` // From the ship's position to the destination
+	Value      int    `json:"value"`    // Cargo value or passenger fare the premium is priced on
+	Premium    int    `json:"premium"`
+	Coverage   int    `json:"coverage"`
+	Insured    bool   `json:"insured"`
+}
+
+// InsuredValue is what a contract's goods are worth where the ship is
+// docked: the quantity times the local unit value (BaseValue lowered by
+// saturation, as in the market history), or the fare for passengers.
+func InsuredValue(s *Ship, c Contract) int {
+	comm := GetCommodity(c.ItemKey)
+	if c.Type != "cargo" || comm == nil {
+		return c.Payout
+	}
+	unit := float64(comm.BaseValue)
+	if heat := Market.DestHeat[s.LocationKey][c.ItemKey]; heat > 0 {
+		unit /= heat
+	}
+	return int(unit) * c.Quantity
+}
+
+// QuoteInsurance prices cover for a contract carried from the ship's
+// current planet to the contract's destination.
+func QuoteInsurance(s *Ship, c Contract) InsuranceQuote {
+	cfg := CurrentUniverse.BalanceConfig
+
+	var dist int64
+	from, to := GetPlanet(s.LocationKey), GetPlanet(c.DestinationKey)
+	if from != nil && to != nil {
+		dist = CalculateDistance(from.Coordinates, to.Coordinates)
+	}
+
+	// 1. Route risk: distance, then hull damage raises the incident odds
+	risk := 1.0
+	if cfg.InsuranceRiskLY > 0 {
+		risk += float64(dist) / cfg.InsuranceRiskLY
+	}
+	risk = HullEventOdds(s, risk)
+
+	// 2. Price against what the goods are worth; the payout caps the claim
+	value := InsuredValue(s, c)
+	quote := InsuranceQuote{
+		ContractID: c.ID,
+		ItemName:   c.ItemName,
+		Distance:   dist,
+		Value:      value,
+		Premium:    int(math.Ceil(float64(value) * cfg.InsuranceBaseRate * risk)),
+		Coverage:   int(float64(c.Payout) * cfg.InsuranceCoverage),
+		Insured:    c.Insured,
+	}
+	if c.Insured {
+		quote.Premium, quote.Coverage = c.Premium, c.Coverage
+	}
+	return quote
+}
+
+// GetInsuranceQuotes prices cover for every contract aboard the ship.
+func GetInsuranceQuotes(s *Ship) []InsuranceQuote {
+	quotes := []InsuranceQuote{}
+	for _, c := range s.ActiveContracts {
+		quotes = append(quotes, QuoteInsurance(s, c))
+	}
+	return quotes
+}
+
+// InsureContract buys cover for one contract aboard a docked ship.
+// An empty contractID insures every uninsured contract aboard (per-trip cover).
+// Refused while an arrival event awaits the player's decision.
+// Returns the total premium paid.
+// Note: Caller must hold DataLock
+func InsureContract(s *Ship, contractID string) (int, error) {
+	if s.Voyage != nil {
+		return 0, errors.New("ship is in flight")
+	}
+	if CurrentPlayer.PendingEvent != nil {
+		return 0, errors.New("resolve the pending event first")
+	}
+
+	// 1. Price everything first so the purchase is all-or-nothing
+	total := 0
+	targets := []int{}
+	for i, c := range s.ActiveContracts {
+		if c.Insured || (contractID != "" && c.ID != contractID) {
+			continue
+		}
+		total += QuoteInsurance(s, c).Premium
+		targets = append(targets, i)
+	}
+	if len(targets) == 0 {
+		return 0, errors.New("nothing to insure")
+	}
+	if CurrentPlayer.Credits < total {
+		return 0, errors.New("insufficient credits")
+	}
+
+	// 2. Commit
+	for _, i := range targets {
+		c := &s.ActiveContracts[i]
+		q := QuoteInsurance(s, *c)
+		c.Insured = true
+		c.Premium = q.Premium
+		c.Coverage = q.Coverage
+		Transact(LedgerInsurance, -q.Premium, contractRef(s, *c, "Insured "+c.ItemName))
+	}
+	return total, nil
+}
+
+// settleClaim pays out cover for a lost contract and records the claim.
+// Returns the payout (0 if the contract was not insured).
+// Note: Caller must hold DataLock
+func settleClaim(s *Ship, c Contract, eventType string) int {
+	if !c.Insured || c.Coverage <= 0 {
+		return 0
+	}
+
+	Transact(LedgerClaim, c.Coverage, contractRef(s, c, "Claim for lost "+c.ItemName))
+	CurrentPlayer.Claims = append(CurrentPlayer.Claims, InsuranceClaim{
+		Tick:       GameClock,
+		Timestamp:  time.Now(),
+		ShipID:     s.InstanceID,
+		ContractID: c.ID,
+		ItemName:   c.ItemName,
+		EventType:  eventType,
+		PlanetKey:  s.LocationKey,
+		Payout:     c.Coverage,
+	})
+	if len(CurrentPlayer.Claims) > maxClaims {
+		CurrentPlayer.Claims = CurrentPlayer.Claims[len(CurrentPlayer.Claims)-maxClaims:]
+	}
+	return c.Coverage
+}
@@ -0,0 +1,194 @@
+/*
+Package game
+File: ledger.go
+Description:
+    The player's financial ledger.
+    Every change to CurrentPlayer.Credits goes through Transact, which
+    records the movement with its type, the resulting balance and the
+    contract/ship/planet it relates to. The ledger is saved with the game,
+    can be filtered for the UI and exported to CSV.
+*/
+
+package game
+
+import (
+	"encoding/csv"
+	"io"
+	"os"
+	"strconv"
+	"time"
+)
+
+// maxLedgerEntries caps how many entries the ledger (and the save file) keeps.
+const maxLedgerEntries = 5000
+
+// Ledger entry types.
+const (
+	LedgerOpening        = "opening_balance"
+	LedgerPayout         = "contract_payout"
+	LedgerFuel           = "fuel"
+	LedgerShipPurchase   = "ship_purchase"
+	LedgerShipSale       = "ship_sale"
+	LedgerModulePurchase = "module_purchase"
+	LedgerModuleSale     = "module_sale"
+	LedgerRepair         = "repair"
+	LedgerMaintenance    = "maintenance"
+	LedgerInsurance      = "insurance_premium"
+	LedgerClaim          = "insurance_claim"
+	LedgerLoan           = "loan"
+	LedgerLoanRepayment  = "loan_repayment"
+	LedgerForeclosure    = "foreclosure_surplus"
+	LedgerTow            = "tow"
+	LedgerTowCredit      = "tow_credit"
+	LedgerDebtRepayment  = "debt_repayment"
+	LedgerTravelEvent    = "travel_event"
+)
+
+// LedgerRef ties a ledger entry to what it was for. All fields are optional.
+type LedgerRef struct {
+	Description string
+	ContractID  string
+	ItemKey     string
+	ShipID      string
+	PlanetKey   string
+}
+
+// LedgerFilter selects ledger entries. Zero-valued fields match everything.
+type LedgerFilter struct {
+	Type       string `json:"type"`
+	ShipID     string `json:"ship_id"`
+	PlanetKey  string `json:"planet_key"`
+	ContractID string `json:"contract_id"`
+	SinceTick  int64  `json:"since_tick"`
+	UntilTick  int64  `json:"until_tick"` // 0 = no upper bound
+	Limit      int    `json:"limit"`      // Most recent N entries; 0 = all
+}
+
+// Transact changes the player's credits by 'amount' and records it.
+// Zero amounts are ignored.
+// Note: Caller must hold DataLock
+func Transact(entryType string, amount int, ref LedgerRef) {
+	if amount == 0 {
+		return
+	}
+	CurrentPlayer.Credits += amount
+	trackCredits()
+
+	var nextID int64 = 1
+	if n := len(Ledger); n > 0 {
+		nextID = Ledger[n-1].ID + 1
+	}
+	Ledger = append(Ledger, LedgerEntry{
+		ID:          nextID,
+		Tick:        GameClock,
+		Timestamp:   time.Now(),
+		Type:        entryType,
+		Amount:      amount,
+		Balance:     CurrentPlayer.Credits,
+		Description: ref.Description,
+		ContractID:  ref.ContractID,
+		ItemKey:     ref.ItemKey,
+		Session:     CurrentSession,
+		ShipID:      ref.ShipID,
+		PlanetKey:   ref.PlanetKey,
+	})
+	if len(Ledger) > maxLedgerEntries {
+		Ledger = Ledger[len(Ledger)-maxLedgerEntries:]
+	}
+}
+
+// shipRef is a LedgerRef for a ship at its current planet.
+func shipRef(s *Ship, description string) LedgerRef {
+	return LedgerRef{Description: description, ShipID: s.InstanceID, PlanetKey: s.LocationKey}
+}
+
+// contractRef is a LedgerRef for a contract aboard a ship.
+func contractRef(s *Ship, c Contract, description string) LedgerRef {
+	ref := shipRef(s, description)
+	ref.ContractID = c.ID
+	ref.ItemKey = c.ItemKey
+	return ref
+}
+
+// Matches reports whether an entry passes the filter.
+func (f LedgerFilter) Matches(e LedgerEntry) bool {
+	switch {
+	case f.Type != "" && e.Type != f.Type:
+		return false
+	case f.ShipID != "" && e.ShipID != f.ShipID:
+		return false
+	case f.PlanetKey != "" && e.PlanetKey != f.PlanetKey:
+		return false
+	case f.ContractID != "" && e.ContractID != f.ContractID:
+		return false
+	case e.Tick < f.SinceTick:
+		return false
+	case f.UntilTick > 0 && e.Tick > f.UntilTick:
+		return false
+	}
+	return true
+}
+
+// GetLedger returns the entries matching the filter, oldest first.
+// Note: Caller must hold DataLock
+func GetLedger(f LedgerFilter) []LedgerEntry {
+	entries := []LedgerEntry{}
+	for _, e := range Ledger {
+		if f.Matches(e) {
+			entries = append(entries, e)
+		}
+	}
+	if f.Limit > 0 && len(entries) > f.Limit {
+		entries = entries[len(entries)-f.Limit:]
+	}
+	return entries
+}
+
+// WriteLedgerCSV writes the matching entries as CSV with a header row.
+// Note: Caller must hold DataLock
+func WriteLedgerCSV(w io.Writer, f LedgerFilter) error {
+	out := csv.NewWriter(w)
+	header := []string{"id", "tick", "timestamp", "type", "amount", "balance", "description", "contract_id", "item_key", "session", "ship_id", "planet_key"}
+	if err := out.Write(header); err != nil {
+		return err
+	}
+
+	for _, e := range GetLedger(f) {
+		row := []string{
+			strconv.FormatInt(e.ID, 10),
+			strconv.FormatInt(e.Tick, 10),
+			e.Timestamp.Format(time.RFC3339),
+			e.Type,
+			strconv.Itoa(e.Amount),
+			strconv.Itoa(e.Balance),
+			e.Description,
+			e.ContractID,
+			e.ItemKey,
+			strconv.Itoa(e.Session),
+			e.ShipID,
+			e.PlanetKey,
+		}
+		if err := out.Write(row); err != nil {
+			return err
+		}
+	}
+
+	out.Flush()
+	return out.Error()
+}
+
+// ExportLedgerCSV writes the matching entries to a CSV file.
+func ExportLedgerCSV(filename string, f LedgerFilter) error {
+	DataLock.RLock()
+	defer DataLock.RUnlock()
+
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	if err := WriteLedgerCSV(file, f); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
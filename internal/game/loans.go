@@ -0,0 +1,244 @@
+/*
+Package game
+File: loans.go
+Description:
+    Bank loans secured against the player's fleet.
+    This includes:
+    1. A credit limit: a share of the fleet's resale value (a smaller
+       share for the captain's active ship), less what is already owed
+       (loans plus tow debt).
+    2. Interest compounding on the game clock, and repayment.
+    3. Default: a loan unpaid at its due tick is called in. The bank first
+       takes the player's credits, then seizes docked fleet ships (never
+       ships in flight). The active ship is not seized: any shortfall is
+       added to the player's debt with a lien on it, so selling it later
+       pays the debt first.
+*/
+
+package game
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Loan statuses.
+const (
+	LoanActive    = "active"
+	LoanRepaid    = "repaid"
+	LoanDefaulted = "defaulted"
+)
+
+// FleetValue is what the player's ships would fetch at a shipyard.
+func FleetValue() int {
+	total := 0
+	for _, s := range CurrentPlayer.Ships {
+		total += ShipResaleValue(s)
+	}
+	return total
+}
+
+// CollateralValue is what the banks will lend against the fleet: the
+// LoanToValue share of each ship's resale value, or the smaller
+// ActiveShipLoanToValue share for the captain's active ship.
+func CollateralValue() int {
+	cfg := CurrentUniverse.BalanceConfig
+	total := 0.0
+	for id, s := range CurrentPlayer.Ships {
+		ratio := cfg.LoanToValue
+		if id == CurrentPlayer.ActiveShipKey {
+			ratio = cfg.ActiveShipLoanToValue
+		}
+		total += float64(ShipResaleValue(s)) * ratio
+	}
+	return int(total)
+}
+
+// TotalOwed is every outstanding loan balance plus tow debt.
+func TotalOwed() int {
+	owed := CurrentPlayer.Debt
+	for _, l := range CurrentPlayer.Loans {
+		if l.Status == LoanActive {
+			owed += l.Balance
+		}
+	}
+	return owed
+}
+
+// CreditLimit is how much more the banks will lend right now.
+func CreditLimit() int {
+	limit := CollateralValue() - TotalOwed()
+	if limit < 0 {
+		return 0
+	}
+	return limit
+}
+
+// TakeLoan borrows 'amount' credits from the bank where the active ship is docked.
+// Note: Caller must hold DataLock
+func TakeLoan(amount int) (*Loan, error) {
+	cfg := CurrentUniverse.BalanceConfig
+	active := CurrentPlayer.Ships[CurrentPlayer.ActiveShipKey]
+	if active == nil || !HasService(active.LocationKey, ServiceBank) {
+		return nil, errors.New("no bank at this location")
+	}
+	if CurrentPlayer.Bankrupt {
+		return nil, errors.New("bankrupt")
+	}
+	if amount < cfg.LoanMinAmount {
+		return nil, fmt.Errorf("minimum loan is %d credits", cfg.LoanMinAmount)
+	}
+	if amount > CreditLimit() {
+		return nil, errors.New("amount exceeds your credit limit")
+	}
+
+	CurrentPlayer.Loans = append(CurrentPlayer.Loans, Loan{
+		ID:           fmt.Sprintf("loan_%d", len(CurrentPlayer.Loans)+1),
+		PlanetKey:    active.LocationKey,
+		Principal:    amount,
+		Balance:      amount,
+		InterestRate: cfg.LoanInterestRate,
+		TakenTick:    GameClock,
+		DueTick:      GameClock + cfg.LoanTermTicks,
+		AccruedTick:  GameClock,
+		Status:       LoanActive,
+	})
+	loan := &CurrentPlayer.Loans[len(CurrentPlayer.Loans)-1]
+	Transact(LedgerLoan, amount, LedgerRef{Description: "Loan " + loan.ID, PlanetKey: loan.PlanetKey})
+	return loan, nil
+}
+
+// RepayLoan pays 'amount' (everything if <= 0) off an active loan.
+// Returns the amount repaid.
+// Note: Caller must hold DataLock
+func RepayLoan(loanID string, amount int) (int, error) {
+	loan := getLoan(loanID)
+	if loan == nil || loan.Status != LoanActive {
+		return 0, errors.New("no active loan with that ID")
+	}
+	if amount <= 0 || amount > loan.Balance {
+		amount = loan.Balance
+	}
+	if amount > CurrentPlayer.Credits {
+		return 0, errors.New("insufficient credits")
+	}
+
+	Transact(LedgerLoanRepayment, -amount, LedgerRef{Description: "Repaid " + loan.ID, PlanetKey: loan.PlanetKey})
+	loan.Balance -= amount
+	if loan.Balance == 0 {
+		loan.Status = LoanRepaid
+	}
+	return amount, nil
+}
+
+// UpdateLoans charges interest up to the current tick and calls in overdue
+// loans. Returns the loans that defaulted during this call.
+func UpdateLoans() []Loan {
+	DataLock.Lock()
+	defer DataLock.Unlock()
+
+	defaulted := []Loan{}
+	for i := range CurrentPlayer.Loans {
+		loan := &CurrentPlayer.Loans[i]
+		if loan.Status != LoanActive {
+			continue
+		}
+
+		// 1. Compound interest for every tick since the last accrual
+		if ticks := GameClock - loan.AccruedTick; ticks > 0 {
+			grown := float64(loan.Balance) * math.Pow(1+loan.InterestRate, float64(ticks))
+			loan.Balance = int(math.Ceil(grown))
+			loan.AccruedTick = GameClock
+		}
+
+		// 2. Overdue: the bank takes what it is owed
+		if GameClock >= loan.DueTick {
+			foreclose(loan)
+			if loan.Status == LoanDefaulted {
+				defaulted = append(defaulted, *loan)
+			}
+		}
+	}
+	return defaulted
+}
+
+// foreclose collects an overdue loan: first from credits (which settles it
+// without a default if they suffice), then by seizing docked fleet ships,
+// most valuable first. Contracts aboard a seized ship go back on the job
+// board where it is docked. The active ship and ships in flight are never
+// taken; any shortfall becomes debt with a lien on the active ship. Whether
+// that debt ends the game is left to CheckBankruptcy.
+// Note: Caller must hold DataLock
+func foreclose(loan *Loan) {
+	// 1. Credits first
+	if paid := min(loan.Balance, CurrentPlayer.Credits); paid > 0 {
+		Transact(LedgerLoanRepayment, -paid, LedgerRef{Description: "Collected on overdue " + loan.ID, PlanetKey: loan.PlanetKey})
+		loan.Balance -= paid
+	}
+	if loan.Balance == 0 {
+		loan.Status = LoanRepaid // Settled in full from credits
+		return
+	}
+
+	// 2. Then ships
+	fleet := []*Ship{}
+	for id, s := range CurrentPlayer.Ships {
+		if id != CurrentPlayer.ActiveShipKey && s.Voyage == nil {
+			fleet = append(fleet, s)
+		}
+	}
+	sort.Slice(fleet, func(i, j int) bool {
+		return ShipResaleValue(fleet[i]) > ShipResaleValue(fleet[j])
+	})
+
+	for _, s := range fleet {
+		if loan.Balance <= 0 {
+			break
+		}
+		loan.Balance -= ShipResaleValue(s)
+		loan.Repossessed = append(loan.Repossessed, s.Name)
+		AvailableContracts[s.LocationKey] = append(AvailableContracts[s.LocationKey], s.ActiveContracts...)
+		delete(CurrentPlayer.Ships, s.InstanceID)
+	}
+
+	if loan.Balance < 0 {
+		// Surplus from the sale is returned
+		Transact(LedgerForeclosure, -loan.Balance, LedgerRef{Description: "Surplus from " + loan.ID + " foreclosure", PlanetKey: loan.PlanetKey})
+	} else if loan.Balance > 0 {
+		CurrentPlayer.Debt += loan.Balance
+		loan.Lien = CurrentPlayer.ActiveShipKey
+	}
+	loan.Balance = 0
+	loan.Status = LoanDefaulted
+
+	headline := fmt.Sprintf("Bank calls in %s's overdue loan", CurrentPlayer.Name)
+	if len(loan.Repossessed) > 0 {
+		headline += fmt.Sprintf("; %d ship(s) repossessed", len(loan.Repossessed))
+	}
+	if active := CurrentPlayer.Ships[loan.Lien]; active != nil {
+		headline += "; lien placed on the " + active.Name
+	}
+	PublishNews("finance", loan.PlanetKey, headline)
+}
+
+// UnderLien reports whether a defaulted loan left a lien on the ship.
+func UnderLien(instanceID string) bool {
+	for _, l := range CurrentPlayer.Loans {
+		if l.Lien == instanceID {
+			return true
+		}
+	}
+	return false
+}
+
+// getLoan finds one of the player's loans by ID.
+func getLoan(loanID string) *Loan {
+	for i := range CurrentPlayer.Loans {
+		if CurrentPlayer.Loans[i].ID == loanID {
+			return &CurrentPlayer.Loans[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+/*
+Package game
+File: maintenance.go
+Description:
+    Engine wear and scheduled maintenance.
+    Every light-year flown adds wear, which slowly raises the burn computed
+    by CalculateCurrentBurn. Shipyards and outfitters service engines for a
+    fee that grows with the wear, resetting it to zero.
+*/
+
+package game
+
+import (
+	"errors"
+	"math"
+)
+
+// EngineWearFactor is the burn multiplier caused by engine wear.
+func EngineWearFactor(s *Ship) float64 {
+	cfg := CurrentUniverse.BalanceConfig
+	return 1.0 + math.Min(cfg.EngineWearCap, float64(s.EngineWear)*cfg.EngineWearPerLY)
+}
+
+// AddEngineWear records 'dist' LY flown since the last service.
+func AddEngineWear(s *Ship, dist int64) {
+	if dist > 0 {
+		s.EngineWear += dist
+	}
+}
+
+// OffersMaintenance reports whether engines can be serviced at the planet.
+func OffersMaintenance(planetKey string) bool {
+	return IsShipyard(planetKey) || HasService(planetKey, ServiceOutfitting)
+}
+
+// MaintenanceCost prices an engine service at the ship's current planet.
+func MaintenanceCost(s *Ship) int {
+	cfg := CurrentUniverse.BalanceConfig
+	fee := cfg.MaintenanceBaseFee + int(s.EngineWear)*cfg.MaintenanceFeePerLY
+	return LocalPrice(s.LocationKey, fee)
+}
+
+// ServiceEngine pays for an engine service and resets wear.
+// Returns the credits spent.
+// Note: Caller must hold DataLock
+func ServiceEngine(s *Ship) (int, error) {
+	if !OffersMaintenance(s.LocationKey) {
+		return 0, errors.New("no maintenance service at this location")
+	}
+	if s.EngineWear <= 0 {
+		return 0, errors.New("engine needs no service")
+	}
+
+	cost := MaintenanceCost(s)
+	if CurrentPlayer.Credits < cost {
+		return 0, errors.New("insufficient credits")
+	}
+
+	Transact(LedgerMaintenance, -cost, shipRef(s, "Engine service"))
+	s.EngineWear = 0
+	return cost, nil
+}
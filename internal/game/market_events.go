@@ -0,0 +1,202 @@
+/*
+Package game
+File: market_events.go
+Description:
+    Runs planet-level economic events (booms, strikes, famines, blockades).
+    Definitions come from the `market_events` section of universe.yaml.
+    While an event is active it can:
+    1. Hold Source/Dest heat away from 1.0 for the affected commodities.
+    2. Scale the size of the planet's job board.
+    3. Block new contracts for some (or all) goods at the planet.
+    Starts and ends are published to the news feed.
+*/
+
+package game
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+)
+
+// UpdateMarketEvents expires finished events and starts new ones.
+// It should run once per heartbeat, before ReplenishMarket.
+// Returns every event that started or ended so the UI can announce it.
+func UpdateMarketEvents() []MarketEvent {
+	DataLock.Lock()
+	defer DataLock.Unlock()
+
+	changed := []MarketEvent{}
+
+	// 1. Expire finished events
+	running := []MarketEvent{}
+	for _, ev := range ActiveMarketEvents {
+		if GameClock >= ev.EndTick {
+			ev.Status = "ended"
+			changed = append(changed, ev)
+			if p := GetPlanet(ev.PlanetKey); p != nil {
+				PublishNews("event", ev.PlanetKey, fmt.Sprintf("%s at %s is over. Markets settling.", ev.Name, p.Name))
+			}
+			continue
+		}
+		running = append(running, ev)
+	}
+	ActiveMarketEvents = running
+
+	// 2. Start scheduled and random events
+	for _, def := range CurrentUniverse.MarketEvents {
+		if !marketEventDue(def) {
+			continue
+		}
+
+		planet := pickEventPlanet(def)
+		if planet == nil || marketEventRunning(def.Key, planet.Key) {
+			continue
+		}
+
+		duration := def.Duration
+		if duration <= 0 {
+			duration = 1
+		}
+
+		ev := MarketEvent{
+			ID:        fmt.Sprintf("MEV-%d-%d", GameClock, rand.Intn(99999)),
+			Key:       def.Key,
+			Name:      def.Name,
+			Headline:  strings.ReplaceAll(def.Headline, "{planet}", planet.Name),
+			PlanetKey: planet.Key,
+			StartTick: GameClock,
+			EndTick:   GameClock + duration,
+			Status:    "active",
+		}
+		ActiveMarketEvents = append(ActiveMarketEvents, ev)
+		changed = append(changed, ev)
+		PublishNews("event", planet.Key, ev.Headline)
+	}
+
+	return changed
+}
+
+// GetActiveMarketEvents returns the events currently affecting the market.
+// Note: Caller must hold DataLock
+func GetActiveMarketEvents() []MarketEvent {
+	if ActiveMarketEvents == nil {
+		return []MarketEvent{}
+	}
+	return ActiveMarketEvents
+}
+
+// marketEventDue decides whether a definition fires on the current tick.
+func marketEventDue(def MarketEventDef) bool {
+	if def.StartTick > 0 && GameClock >= def.StartTick {
+		if GameClock == def.StartTick {
+			return true
+		}
+		if def.RepeatEvery > 0 && (GameClock-def.StartTick)%def.RepeatEvery == 0 {
+			return true
+		}
+	}
+	return def.Chance > 0 && rand.Float64() < def.Chance
+}
+
+// pickEventPlanet chooses where an event happens from its eligible planets.
+func pickEventPlanet(def MarketEventDef) *Planet {
+	if len(def.Planets) == 0 {
+		if len(CurrentUniverse.Planets) == 0 {
+			return nil
+		}
+		return &CurrentUniverse.Planets[rand.Intn(len(CurrentUniverse.Planets))]
+	}
+	return GetPlanet(def.Planets[rand.Intn(len(def.Planets))])
+}
+
+// marketEventRunning reports whether the same event is already active at the planet.
+func marketEventRunning(key, planetKey string) bool {
+	for _, ev := range ActiveMarketEvents {
+		if ev.Key == key && ev.PlanetKey == planetKey {
+			return true
+		}
+	}
+	return false
+}
+
+// eventAffectsItem reports whether a definition applies to a commodity.
+func eventAffectsItem(def *MarketEventDef, itemKey string) bool {
+	if len(def.Items) == 0 {
+		return true
+	}
+	for _, k := range def.Items {
+		if k == itemKey {
+			return true
+		}
+	}
+	return false
+}
+
+// applyMarketEventHeat holds heat at the shifted level for active events.
+// Recovery in MarketTick pulls heat back towards 1.0; this runs after it so
+// the shock persists until the event ends, then fades naturally.
+// Note: Caller must hold DataLock
+func applyMarketEventHeat() {
+	for _, ev := range ActiveMarketEvents {
+		def := GetMarketEventDef(ev.Key)
+		if def == nil {
+			continue
+		}
+		for cKey := range Market.SourceHeat[ev.PlanetKey] {
+			if !eventAffectsItem(def, cKey) {
+				continue
+			}
+			if def.SourceHeatShift != 0 {
+				Market.SourceHeat[ev.PlanetKey][cKey] = holdHeat(Market.SourceHeat[ev.PlanetKey][cKey], def.SourceHeatShift)
+			}
+			if def.DestHeatShift != 0 && Market.DestHeat[ev.PlanetKey] != nil {
+				Market.DestHeat[ev.PlanetKey][cKey] = holdHeat(Market.DestHeat[ev.PlanetKey][cKey], def.DestHeatShift)
+			}
+		}
+	}
+}
+
+// holdHeat keeps heat at least 'shift' away from neutral in the shift's direction.
+func holdHeat(heat, shift float64) float64 {
+	target := math.Max(0.1, 1.0+shift)
+	if shift > 0 {
+		return math.Max(heat, target)
+	}
+	return math.Min(heat, target)
+}
+
+// marketEventVolume returns the combined job board multiplier for a planet.
+// Note: Caller must hold DataLock
+func marketEventVolume(planetKey string) float64 {
+	mult := 1.0
+	for _, ev := range ActiveMarketEvents {
+		if ev.PlanetKey != planetKey {
+			continue
+		}
+		if def := GetMarketEventDef(ev.Key); def != nil && def.VolumeMult > 0 {
+			mult *= def.VolumeMult
+		}
+	}
+	return mult
+}
+
+// isBlockaded reports whether new contracts for an item are blocked at a planet.
+// Pass an empty itemKey to ask whether the whole planet is shut down.
+// Note: Caller must hold DataLock
+func isBlockaded(planetKey, itemKey string) bool {
+	for _, ev := range ActiveMarketEvents {
+		if ev.PlanetKey != planetKey {
+			continue
+		}
+		def := GetMarketEventDef(ev.Key)
+		if def == nil || !def.Blocked {
+			continue
+		}
+		if len(def.Items) == 0 || (itemKey != "" && eventAffectsItem(def, itemKey)) {
+			return true
+		}
+	}
+	return false
+}
@@ -42,6 +42,36 @@ func GetModule(key string) *ShipModule {
 	return nil
 }
 
+// GetShipTemplate is a helper to retrieve a ShipTemplate pointer by its Key.
+func GetShipTemplate(key string) *ShipTemplate {
+	for _, t := range CurrentUniverse.ShipTemplates {
+		if t.Key == key {
+			return &t
+		}
+	}
+	return nil
+}
+
+// GetRecipe is a helper to retrieve a Recipe pointer by its Key.
+func GetRecipe(key string) *Recipe {
+	for _, r := range CurrentUniverse.Recipes {
+		if r.Key == key {
+			return &r
+		}
+	}
+	return nil
+}
+
+// GetMarketEventDef is a helper to retrieve a MarketEventDef pointer by its Key.
+func GetMarketEventDef(key string) *MarketEventDef {
+	for _, e := range CurrentUniverse.MarketEvents {
+		if e.Key == key {
+			return &e
+		}
+	}
+	return nil
+}
+
 // CalculateDistance computes the Euclidean distance between two 2D coordinates.
 // It rounds to the nearest integer for game simplicity.
 func CalculateDistance(p1, p2 []int) int64 {
@@ -53,6 +83,23 @@ func CalculateDistance(p1, p2 []int) int64 {
 	return int64(math.Round(dist))
 }
 
+// CanCarry reports whether the ship has a free cargo bay or seat for the contract.
+func CanCarry(s *Ship, c Contract) bool {
+	currentCargo, currentPax := 0, 0
+	for _, ac := range s.ActiveContracts {
+		if ac.Type == "cargo" {
+			currentCargo += ac.Quantity
+		} else {
+			currentPax += ac.Quantity
+		}
+	}
+
+	if c.Type == "cargo" {
+		return currentCargo+c.Quantity <= s.CargoCapacity
+	}
+	return currentPax+c.Quantity <= s.PassengerSlots
+}
+
 // CalculateTotalMass computes the current weight of the SPECIFIED ship.
 // Formula: BaseMass + (Cargo_Qty * Mass) + (Pax_Qty * Mass) + FuelMass
 func CalculateTotalMass(s *Ship) int64 {
@@ -76,15 +123,21 @@ func CalculateTotalMass(s *Ship) int64 {
 
 // CalculateCurrentBurn determines the fuel cost per Light Year for the SPECIFIED ship.
 // Formula: BaseBurn + ((CurrentMass - ReferenceMass) / Damping)
-// ReferenceMass = Ship Empty + 50% Fuel.
+// ReferenceMass = Stock Hull Empty + 50% Stock Fuel.
 func CalculateCurrentBurn(s *Ship) int64 {
 	currentMass := CalculateTotalMass(s)
 
 	// 1. Calculate Reference Mass (The "Control" state)
-	// The ship is tuned to perform at BaseBurnRate when it has exactly 50% fuel and 0 cargo.
-	halfFuel := s.MaxFuel / 2
+	// The hull is tuned to perform at BaseBurnRate when it has exactly 50% fuel and 0 cargo.
+	// The reference uses the unmodified template, so modules that add mass or
+	// tank capacity change the burn instead of cancelling out.
+	baseMass, maxFuel := s.BaseMass, s.MaxFuel
+	if t := GetShipTemplate(s.TemplateKey); t != nil {
+		baseMass, maxFuel = t.BaseMass, t.MaxFuel
+	}
+	halfFuel := maxFuel / 2
 	halfFuelMass := halfFuel * int64(CurrentUniverse.BalanceConfig.FuelMassPerUnit)
-	referenceMass := s.BaseMass + halfFuelMass
+	referenceMass := baseMass + halfFuelMass
 
 	// 2. Determine Mass Delta
 	// Positive = Heavier than reference (Burn Penalty)
@@ -98,7 +151,15 @@ func CalculateCurrentBurn(s *Ship) int64 {
 
 	finalBurn := s.BaseBurnRate + burnAdjustment
 
-	// 4. Safety Clamp
+	// 4. Hull Condition
+	// A battered hull leaks efficiency: up to HullBurnPenalty extra burn at zero condition.
+	finalBurn = int64(float64(finalBurn) * HullBurnFactor(s))
+
+	// 5. Engine Wear
+	// Every LY since the last service adds a little burn, up to EngineWearCap.
+	finalBurn = int64(float64(finalBurn) * EngineWearFactor(s))
+
+	// 6. Safety Clamp
 	// Prevent free travel or negative burn if the ship is extremely light.
 	if finalBurn < 100 {
 		return 100
@@ -1,17 +1,75 @@
 package game
 
+import "time"
+
 type GameBalance struct {
 	StartingCredits    int `yaml:"starting_credits" json:"starting_credits"`
 	FuelCostPerUnit    int `yaml:"fuel_cost_per_unit" json:"fuel_cost_per_unit"`
 	FuelMassPerUnit    int `yaml:"fuel_mass_per_unit" json:"fuel_mass_per_unit"`
 	DistancePayoutMult int `yaml:"distance_payout_mult" json:"distance_payout_mult"`
+	StockpileCap       int `yaml:"stockpile_cap" json:"stockpile_cap"`
+
+	HeatDiffusionRate  float64 `yaml:"heat_diffusion_rate" json:"heat_diffusion_rate"`
+	HeatDiffusionRange int64   `yaml:"heat_diffusion_range" json:"heat_diffusion_range"`
+
+	MarketHistoryLength int `yaml:"market_history_length" json:"market_history_length"`
+
+	NewsHeatSwing float64 `yaml:"news_heat_swing" json:"news_heat_swing"` // DestHeat change per tick worth a headline
+	NewsBigPayout int     `yaml:"news_big_payout" json:"news_big_payout"` // Single-arrival payout worth a headline
+	NewsRivalHaul int     `yaml:"news_rival_haul" json:"news_rival_haul"` // Rival arrival payout worth a headline
+
+	ShipResaleRate   float64 `yaml:"ship_resale_rate" json:"ship_resale_rate"`     // Share of list price paid for a used ship
+	ModuleResaleRate float64 `yaml:"module_resale_rate" json:"module_resale_rate"` // Share of list price paid for a used module
+
+	HullRepairCost   int     `yaml:"hull_repair_cost" json:"hull_repair_cost"`     // Credits per hull point repaired
+	HullBurnPenalty  float64 `yaml:"hull_burn_penalty" json:"hull_burn_penalty"`   // Extra burn share at zero condition
+	HullEventPenalty float64 `yaml:"hull_event_penalty" json:"hull_event_penalty"` // Extra incident odds share at zero condition
+
+	EngineWearPerLY     float64 `yaml:"engine_wear_per_ly" json:"engine_wear_per_ly"`         // Extra burn share per LY since last service
+	EngineWearCap       float64 `yaml:"engine_wear_cap" json:"engine_wear_cap"`               // Maximum extra burn share from wear
+	MaintenanceBaseFee  int     `yaml:"maintenance_base_fee" json:"maintenance_base_fee"`     // Flat fee per engine service
+	MaintenanceFeePerLY int     `yaml:"maintenance_fee_per_ly" json:"maintenance_fee_per_ly"` // Plus this per LY of wear
+
+	FuelDepotDiscount float64 `yaml:"fuel_depot_discount" json:"fuel_depot_discount"` // Fuel price multiplier at fuel depots
+
+	TowBaseFee      int     `yaml:"tow_base_fee" json:"tow_base_fee"`           // Flat fee for an emergency tow
+	TowFeePerLY     int     `yaml:"tow_fee_per_ly" json:"tow_fee_per_ly"`       // Plus this per LY towed
+	TowFuelShare    float64 `yaml:"tow_fuel_share" json:"tow_fuel_share"`       // Tank share filled on arrival at the depot
+	MaxDebt         int     `yaml:"max_debt" json:"max_debt"`                   // Debt beyond this means bankruptcy
+	DebtGarnishRate float64 `yaml:"debt_garnish_rate" json:"debt_garnish_rate"` // Share of each payout taken to repay debt
+
+	LoanInterestRate      float64 `yaml:"loan_interest_rate" json:"loan_interest_rate"`               // Compounded per game tick
+	LoanTermTicks         int64   `yaml:"loan_term_ticks" json:"loan_term_ticks"`                     // Ticks until a loan falls due
+	LoanToValue           float64 `yaml:"loan_to_value" json:"loan_to_value"`                         // Credit limit as a share of fleet value
+	ActiveShipLoanToValue float64 `yaml:"active_ship_loan_to_value" json:"active_ship_loan_to_value"` // Smaller share for the active ship, which is liened rather than seized
+	LoanMinAmount         int     `yaml:"loan_min_amount" json:"loan_min_amount"`
+
+	InsuranceBaseRate float64 `yaml:"insurance_base_rate" json:"insurance_base_rate"` // Premium as a share of cargo value on a short hop
+	InsuranceRiskLY   float64 `yaml:"insurance_risk_ly" json:"insurance_risk_ly"`     // Each this-many LY adds the base rate again
+	InsuranceCoverage float64 `yaml:"insurance_coverage" json:"insurance_coverage"`   // Share of payout refunded on a claim
+}
+
+// ModuleEffect is a single stat change applied by an installed module.
+// Stats: cargo_capacity, passenger_slots, max_fuel, base_burn_rate,
+// burn_damping, base_mass, max_hull. Additive effects apply before multiplicative ones.
+type ModuleEffect struct {
+	Stat  string  `yaml:"stat" json:"stat"`
+	Mode  string  `yaml:"mode" json:"mode"` // "add" (default) or "mult"
+	Value float64 `yaml:"value" json:"value"`
 }
 
 type ShipModule struct {
-	Key          string `yaml:"key" json:"key"`
-	Name         string `yaml:"name" json:"name"`
-	Description  string `yaml:"description" json:"description"`
-	Cost         int    `yaml:"cost" json:"cost"`
+	Key         string         `yaml:"key" json:"key"`
+	Name        string         `yaml:"name" json:"name"`
+	Description string         `yaml:"description" json:"description"`
+	Cost        int            `yaml:"cost" json:"cost"`
+	Effects     []ModuleEffect `yaml:"effects" json:"effects"`
+	Restricted  bool           `yaml:"restricted" json:"restricted"` // Only sold on the black market
+	SlotType    string         `yaml:"slot_type" json:"slot_type"`   // "engine", "hold", "cabin" or "utility"
+	Size        int            `yaml:"size" json:"size"`             // Smallest slot size it fits (default 1)
+	Slot        int            `yaml:"-" json:"slot"`                // Index into Ship.Slots while installed, -1 otherwise
+
+	// Legacy single-stat form, still honoured for older configs and saves.
 	StatModifier string `yaml:"stat_modifier" json:"stat_modifier"`
 	StatValue    int    `yaml:"stat_value" json:"stat_value"`
 }
@@ -26,6 +84,22 @@ type Contract struct {
 	OriginKey      string `json:"origin_key"`
 	DestinationKey string `json:"destination_key"`
 	Payout         int    `json:"payout"`
+
+	Insured  bool `json:"insured"`  // Covered against loss in transit
+	Premium  int  `json:"premium"`  // What the cover cost
+	Coverage int  `json:"coverage"` // Paid out if the contract is lost
+}
+
+// InsuranceClaim is a payout received for an insured contract lost in transit.
+type InsuranceClaim struct {
+	Tick       int64     `json:"tick"`
+	Timestamp  time.Time `json:"timestamp"`
+	ShipID     string    `json:"ship_id"`
+	ContractID string    `json:"contract_id"`
+	ItemName   string    `json:"item_name"`
+	EventType  string    `json:"event_type"` // Arrival event type, e.g. "cargo_loss"
+	PlanetKey  string    `json:"planet_key"` // Where the loss was reported
+	Payout     int       `json:"payout"`
 }
 
 type Planet struct {
@@ -38,6 +112,19 @@ type Planet struct {
 	MaxCargo      int      `json:"max_cargo" yaml:"max_cargo"`
 	MinPassengers int      `json:"min_passengers" yaml:"min_passengers"`
 	MaxPassengers int      `json:"max_passengers" yaml:"max_passengers"`
+	Recipes       []string `json:"recipes" yaml:"recipes"`
+
+	Services      []string      `json:"services" yaml:"services"`               // "shipyard", "fuel_depot", "outfitting", "black_market", "bank"
+	FuelPriceMult float64       `json:"fuel_price_mult" yaml:"fuel_price_mult"` // Local fuel markup (default 1.0)
+	Shipyard      ShipyardStock `json:"shipyard" yaml:"shipyard"`
+}
+
+// ShipyardStock lists what a planet's shipyard and outfitters sell, and the
+// local markup over list price. Empty lists mean the full catalogue.
+type ShipyardStock struct {
+	PriceMult float64  `json:"price_mult" yaml:"price_mult"`
+	Ships     []string `json:"ships" yaml:"ships"`
+	Modules   []string `json:"modules" yaml:"modules"`
 }
 
 // Player represents the human user.
@@ -46,20 +133,92 @@ type Player struct {
 	Credits       int              `json:"credits"`
 	Ships         map[string]*Ship `json:"ships"`           // Map of [InstanceID] -> Ship
 	ActiveShipKey string           `json:"active_ship_key"` // The ID of the ship currently being flown
+	ModuleStorage []ShipModule     `json:"module_storage"`  // Uninstalled modules awaiting refit or sale
+	Debt          int              `json:"debt"`            // Owed for emergency tows; garnished from payouts
+	Bankrupt      bool             `json:"bankrupt"`        // Game over: stranded with no way to pay for rescue
+	Loans         []Loan           `json:"loans"`           // Every bank loan taken, including settled ones
+	Claims        []InsuranceClaim `json:"claims"`          // Insurance payouts received
+
+	Stats        LifetimeStats         `json:"stats"`
+	Achievements []UnlockedAchievement `json:"achievements"`
+	PendingEvent *PendingEvent         `json:"pending_event"` // Awaiting a choice; blocks travel
+}
+
+// LifetimeStats accumulates over the whole game across every player ship.
+type LifetimeStats struct {
+	DistanceTravelled  int64          `json:"distance_travelled"` // LY
+	Trips              int            `json:"trips"`
+	ContractsCompleted map[string]int `json:"contracts_completed"` // By contract type: "cargo", "passenger"
+	CargoDelivered     int            `json:"cargo_delivered"`     // Units
+	PassengersCarried  int            `json:"passengers_carried"`
+	CreditsEarned      int            `json:"credits_earned"` // Contract payouts
+	FuelBurned         int64          `json:"fuel_burned"`    // Hundredths, like Ship.Fuel
+	IncidentsSurvived  int            `json:"incidents_survived"`
+	PeakCredits        int            `json:"peak_credits"`
+}
+
+// Achievement is a milestone defined in universe.yaml. It unlocks once
+// every condition is met.
+type Achievement struct {
+	Key         string                 `yaml:"key" json:"key"`
+	Name        string                 `yaml:"name" json:"name"`
+	Description string                 `yaml:"description" json:"description"`
+	Conditions  []AchievementCondition `yaml:"conditions" json:"conditions"`
+}
+
+// AchievementCondition requires a lifetime stat to reach Min.
+// Stats are listed in achievements.go (statValue).
+type AchievementCondition struct {
+	Stat string  `yaml:"stat" json:"stat"`
+	Min  float64 `yaml:"min" json:"min"`
+}
+
+// UnlockedAchievement records when the player earned an achievement.
+type UnlockedAchievement struct {
+	Key       string    `json:"key"`
+	Tick      int64     `json:"tick"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Loan is money borrowed from a bank against the player's fleet.
+// Interest compounds on the game clock until the balance is repaid.
+type Loan struct {
+	ID           string   `json:"id"`
+	PlanetKey    string   `json:"planet_key"` // Bank that issued it
+	Principal    int      `json:"principal"`
+	Balance      int      `json:"balance"`       // Outstanding, including accrued interest
+	InterestRate float64  `json:"interest_rate"` // Per game tick
+	TakenTick    int64    `json:"taken_tick"`
+	DueTick      int64    `json:"due_tick"`
+	AccruedTick  int64    `json:"accrued_tick"` // Interest has been charged up to this tick
+	Status       string   `json:"status"`       // "active", "repaid", "defaulted"
+	Repossessed  []string `json:"repossessed"`  // Ship names seized on default
+	Lien         string   `json:"lien"`         // Ship ID securing the shortfall left as debt, if any
 }
 
 // ShipTemplate defines the base stats for a model of ship (loaded from YAML).
 type ShipTemplate struct {
-	Key            string `yaml:"key"`
-	Name           string `yaml:"name"`
-	Description    string `yaml:"description"`
-	MaxFuel        int64  `yaml:"max_fuel"`
-	BaseBurnRate   int64  `yaml:"base_burn_rate"`
-	BurnDamping    int64  `yaml:"burn_damping"`
-	BaseMass       int64  `yaml:"base_mass"`
-	CargoCapacity  int    `yaml:"cargo_capacity"`
-	PassengerSlots int    `yaml:"passenger_slots"`
-	MaxModuleSlots int    `yaml:"max_module_slots"`
+	Key            string `yaml:"key" json:"key"`
+	Name           string `yaml:"name" json:"name"`
+	Description    string `yaml:"description" json:"description"`
+	Cost           int    `yaml:"cost" json:"cost"` // Shipyard purchase price
+	MaxFuel        int64  `yaml:"max_fuel" json:"max_fuel"`
+	BaseBurnRate   int64  `yaml:"base_burn_rate" json:"base_burn_rate"`
+	BurnDamping    int64  `yaml:"burn_damping" json:"burn_damping"`
+	BaseMass       int64  `yaml:"base_mass" json:"base_mass"`
+	CargoCapacity  int    `yaml:"cargo_capacity" json:"cargo_capacity"`
+	PassengerSlots int    `yaml:"passenger_slots" json:"passenger_slots"`
+	MaxModuleSlots int    `yaml:"max_module_slots" json:"max_module_slots"` // Legacy: untyped slots when Slots is empty
+	MaxHull        int    `yaml:"max_hull" json:"max_hull"`
+
+	Slots []ModuleSlot `yaml:"slots" json:"slots"` // Typed hardpoints
+}
+
+// ModuleSlot is a typed hardpoint on a hull. A module fits a slot of its
+// own type whose size is at least the module's size.
+type ModuleSlot struct {
+	Type string `yaml:"type" json:"type"`
+	Size int    `yaml:"size" json:"size"`
 }
 
 // Ship represents a specific instance of a vessel owned by a player.
@@ -79,14 +238,146 @@ type Ship struct {
 	PassengerSlots int `json:"passenger_slots"`
 	MaxModuleSlots int `json:"max_module_slots"`
 
+	Hull    int `json:"hull"` // Structural condition; damaged by incidents, restored at shipyards
+	MaxHull int `json:"max_hull"`
+
+	EngineWear int64 `json:"engine_wear"` // LY travelled since the last engine service
+
+	Slots            []ModuleSlot `json:"slots"`
 	InstalledModules []ShipModule `json:"installed_modules"`
 	ActiveContracts  []Contract   `json:"active_contracts"`
 
+	// Automation (non-active fleet ships only)
+	Orders *ShipOrders `json:"orders"`
+	Voyage *Voyage     `json:"voyage"` // Set while flying on the game clock
+
 	// Dynamic Fields
 	TotalMass   int64 `json:"total_mass" yaml:"-"`
 	CurrentBurn int64 `json:"current_burn" yaml:"-"`
 }
 
+// ShipOrders are standing instructions the economy heartbeat executes for
+// an idle fleet ship.
+//   - "route":    Loop through Stops, carrying contracts to each next stop.
+//   - "standing": Haul anything from OriginKey to DestinationKey, then return.
+type ShipOrders struct {
+	Mode           string   `json:"mode"`
+	Stops          []string `json:"stops"`
+	NextStop       int      `json:"next_stop"`
+	OriginKey      string   `json:"origin_key"`
+	DestinationKey string   `json:"destination_key"`
+	MinPayout      int      `json:"min_payout"` // Ignore contracts paying less than this
+}
+
+// Voyage tracks a ship travelling on the game clock rather than instantly.
+type Voyage struct {
+	OriginKey      string `json:"origin_key"`
+	DestinationKey string `json:"destination_key"`
+	ArrivalTick    int64  `json:"arrival_tick"`
+	Distance       int64  `json:"distance"`
+	FuelBurned     int64  `json:"fuel_burned"`
+}
+
+// TripRecord is one completed journey by a player-owned ship.
+type TripRecord struct {
+	Tick           int64     `yaml:"tick" json:"tick"`
+	Timestamp      time.Time `yaml:"timestamp" json:"timestamp"`
+	ShipID         string    `yaml:"ship_id" json:"ship_id"`
+	ShipName       string    `yaml:"ship_name" json:"ship_name"`
+	OriginKey      string    `yaml:"origin_key" json:"origin_key"`
+	DestinationKey string    `yaml:"destination_key" json:"destination_key"`
+	Distance       int64     `yaml:"distance" json:"distance"`
+	FuelBurned     int64     `yaml:"fuel_burned" json:"fuel_burned"`
+	FuelCost       int       `yaml:"fuel_cost" json:"fuel_cost"` // Credit value of the fuel burned
+	Delivered      int       `yaml:"delivered" json:"delivered"` // Contracts completed on arrival
+	Payout         int       `yaml:"payout" json:"payout"`
+	Automated      bool      `yaml:"automated" json:"automated"`
+}
+
+// LedgerEntry is one movement of the player's credits.
+// Amount is signed: positive for income, negative for spending.
+type LedgerEntry struct {
+	ID          int64     `yaml:"id" json:"id"`
+	Tick        int64     `yaml:"tick" json:"tick"`
+	Timestamp   time.Time `yaml:"timestamp" json:"timestamp"`
+	Type        string    `yaml:"type" json:"type"` // See the Ledger* constants in ledger.go
+	Amount      int       `yaml:"amount" json:"amount"`
+	Balance     int       `yaml:"balance" json:"balance"` // Credits after this entry
+	Description string    `yaml:"description" json:"description"`
+	ContractID  string    `yaml:"contract_id" json:"contract_id"`
+	ItemKey     string    `yaml:"item_key" json:"item_key"` // Commodity (or passenger class) of the contract
+	Session     int       `yaml:"session" json:"session"`   // Play session the entry was made in
+	ShipID      string    `yaml:"ship_id" json:"ship_id"`
+	PlanetKey   string    `yaml:"planet_key" json:"planet_key"`
+}
+
+// NPCConfig controls the simulated traffic of AI haulers (loaded from YAML).
+type NPCConfig struct {
+	Count           int      `yaml:"count"`
+	Names           []string `yaml:"names"`
+	Strategies      []string `yaml:"strategies"`     // "greedy", "local", "passenger"
+	ShipTemplates   []string `yaml:"ship_templates"` // Template keys NPCs may fly
+	StartingCredits int      `yaml:"starting_credits"`
+	LYPerTick       int64    `yaml:"ly_per_tick"` // Travel speed on the game clock
+	ActChance       float64  `yaml:"act_chance"`  // Odds a docked NPC looks for work each tick
+	LocalRange      int64    `yaml:"local_range"` // Max route length for the "local" strategy
+}
+
+// NPCTrader is a simulated hauler that competes with the player for contracts.
+// While DestinationKey is set the trader is in transit and arrives at ArrivalTick.
+// Named rival captains are NPCTraders with a Personality.
+type NPCTrader struct {
+	ID             string `yaml:"id" json:"id"`
+	Name           string `yaml:"name" json:"name"`
+	Strategy       string `yaml:"strategy" json:"strategy"`
+	Credits        int    `yaml:"credits" json:"credits"`
+	Ship           *Ship  `yaml:"ship" json:"ship"`
+	DestinationKey string `yaml:"destination_key" json:"destination_key"`
+	ArrivalTick    int64  `yaml:"arrival_tick" json:"arrival_tick"`
+
+	Personality        string  `yaml:"personality" json:"personality"`                 // Rivals only
+	ActChance          float64 `yaml:"act_chance" json:"act_chance"`                   // 0 = NPCConfig default
+	ContractsDelivered int     `yaml:"contracts_delivered" json:"contracts_delivered"` // Lifetime deliveries
+}
+
+// RivalCaptain describes a named, persistent rival (loaded from YAML).
+type RivalCaptain struct {
+	Key          string `yaml:"key"`
+	Name         string `yaml:"name"`
+	Personality  string `yaml:"personality"` // "aggressive", "cautious", "passenger"
+	ShipTemplate string `yaml:"ship_template"`
+	HomeKey      string `yaml:"home"`
+}
+
+// AIDifficulty tunes how strong rival captains play.
+type AIDifficulty struct {
+	Key             string  `yaml:"key" json:"key"`
+	Name            string  `yaml:"name" json:"name"`
+	StartingCredits int     `yaml:"starting_credits" json:"starting_credits"`
+	ActChance       float64 `yaml:"act_chance" json:"act_chance"` // Odds a docked rival takes work each tick
+}
+
+// RivalConfig groups the rival captains and the selectable AI strengths.
+type RivalConfig struct {
+	DefaultDifficulty string         `yaml:"default_difficulty"`
+	Difficulties      []AIDifficulty `yaml:"difficulties"`
+	Captains          []RivalCaptain `yaml:"captains"`
+}
+
+// RivalStanding is one row of the captains' standings table.
+type RivalStanding struct {
+	Rank               int    `json:"rank"`
+	Name               string `json:"name"`
+	Personality        string `json:"personality"`
+	IsPlayer           bool   `json:"is_player"`
+	Credits            int    `json:"credits"`
+	ShipName           string `json:"ship_name"`
+	ShipTemplateKey    string `json:"ship_template_key"`
+	LocationKey        string `json:"location_key"`
+	DestinationKey     string `json:"destination_key"`
+	ContractsDelivered int    `json:"contracts_delivered"`
+}
+
 type TravelEvent struct {
 	Type        string `json:"type"`
 	Description string `json:"description"`
@@ -105,23 +396,200 @@ type Commodity struct {
 	Mass      int    `yaml:"mass" json:"mass"`
 }
 
+// RecipeComponent is a single input or output line of a production Recipe.
+type RecipeComponent struct {
+	ItemKey  string `yaml:"item" json:"item_key"`
+	Quantity int    `yaml:"quantity" json:"quantity"`
+}
+
+// Recipe converts delivered input goods into manufactured output goods.
+// Planets opt in by listing the recipe key in their `recipes` field.
+type Recipe struct {
+	Key           string            `yaml:"key" json:"key"`
+	Name          string            `yaml:"name" json:"name"`
+	Inputs        []RecipeComponent `yaml:"inputs" json:"inputs"`
+	Outputs       []RecipeComponent `yaml:"outputs" json:"outputs"`
+	CyclesPerTick int               `yaml:"cycles_per_tick" json:"cycles_per_tick"` // Max batches per market tick
+	StartingStock int               `yaml:"starting_stock" json:"starting_stock"`   // Output units on hand at game start
+}
+
+// ArrivalEventDef describes a random incident rolled when a ship arrives
+// (loaded from YAML). It fires with Probability when every condition holds.
+type ArrivalEventDef struct {
+	Key           string            `yaml:"key" json:"key"`
+	Type          string            `yaml:"type" json:"type"`               // TravelEvent.Type reported to the UI
+	Description   string            `yaml:"description" json:"description"` // "{item}", "{origin}", "{destination}" are substituted
+	Effect        string            `yaml:"effect" json:"effect"`           // Optional summary; generated from Effects if empty
+	Probability   float64           `yaml:"probability" json:"probability"`
+	HullSensitive bool              `yaml:"hull_sensitive" json:"hull_sensitive"` // Odds rise as the hull degrades
+	Conditions    ArrivalConditions `yaml:"conditions" json:"conditions"`
+	Effects       []ArrivalEffect   `yaml:"effects" json:"effects"`
+	Choices       []EventChoice     `yaml:"choices" json:"choices"` // If set, the event pauses for the captain to choose
+}
+
+// EventChoice is one option offered by a player-choice arrival event.
+// One outcome is picked at random, weighted by Weight.
+type EventChoice struct {
+	Key      string         `yaml:"key" json:"key"`
+	Label    string         `yaml:"label" json:"label"`
+	Outcomes []EventOutcome `yaml:"outcomes" json:"outcomes"`
+}
+
+// EventOutcome is a possible result of an EventChoice.
+type EventOutcome struct {
+	Weight      float64         `yaml:"weight" json:"weight"`
+	Description string          `yaml:"description" json:"description"` // Same placeholders as ArrivalEventDef
+	Effects     []ArrivalEffect `yaml:"effects" json:"effects"`
+}
+
+// PendingEvent is a player-choice event waiting for the captain's decision.
+// The arrival's deliveries are held, and travel blocked, until it is resolved.
+type PendingEvent struct {
+	ID          string          `json:"id"`
+	Key         string          `json:"key"` // ArrivalEventDef key
+	Type        string          `json:"type"`
+	Description string          `json:"description"`
+	Choices     []PendingChoice `json:"choices"`
+	ShipID      string          `json:"ship_id"`
+	OriginKey   string          `json:"origin_key"`
+	Distance    int64           `json:"distance"`
+	FuelBurned  int64           `json:"fuel_burned"`          // For the trip log once the arrival completes
+	SubjectID   string          `json:"subject_id,omitempty"` // Contract the event is about, if any
+	SubjectItem string          `json:"subject_item,omitempty"`
+	Tick        int64           `json:"tick"`
+}
+
+// PendingChoice is an option shown to the player for a PendingEvent.
+type PendingChoice struct {
+	ID    string `json:"id"`
+	Label string `json:"label"`
+}
+
+// ArrivalConditions restrict when an arrival event can fire.
+// Zero-valued fields are ignored.
+type ArrivalConditions struct {
+	ContractType string   `yaml:"contract_type" json:"contract_type"` // A "cargo" or "passenger" contract must be aboard
+	Items        []string `yaml:"items" json:"items"`                 // One of these commodities must be aboard
+	MinDistance  int64    `yaml:"min_distance" json:"min_distance"`   // Route length in LY
+	MaxDistance  int64    `yaml:"max_distance" json:"max_distance"`
+	Origins      []string `yaml:"origins" json:"origins"`
+	Destinations []string `yaml:"destinations" json:"destinations"`
+	MaxHull      float64  `yaml:"max_hull" json:"max_hull"`     // Only when hull condition (0-1) is at or below this
+	MinHull      float64  `yaml:"min_hull" json:"min_hull"`     // Only when hull condition (0-1) is at or above this
+	BoundHere    bool     `yaml:"bound_here" json:"bound_here"` // Matching contracts must be bound for the arrival planet
+}
+
+// ArrivalEffect is one consequence of an arrival event.
+//   - "fuel_loss":       Lose Min-Max share of current fuel.
+//   - "remove_contract": Lose one contract matching the event's conditions.
+//   - "credits":         Gain (or, if negative, pay) Min-Max credits.
+//   - "hull_damage":     Lose Min-Max share of maximum hull.
+//   - "fuel_gain":       Gain Min-Max share of maximum fuel, up to a full tank.
+//   - "tip":             Receive Min-Max share of the payout of a contract
+//     matching the event's conditions that is bound for this planet.
+//   - "salvage":         Take aboard Min-Max units of free cargo, bound for
+//     another planet (trimmed to free hold space).
+//   - "tip_off":         Post a contract for MinQty-MaxQty units on the local
+//     board, paying Min-Max times the usual rate.
+type ArrivalEffect struct {
+	Type   string   `yaml:"type" json:"type"`
+	Min    float64  `yaml:"min" json:"min"`
+	Max    float64  `yaml:"max" json:"max"`
+	MinQty int      `yaml:"min_qty" json:"min_qty"` // Quantity range (tip_off)
+	MaxQty int      `yaml:"max_qty" json:"max_qty"`
+	Items  []string `yaml:"items" json:"items"` // Commodities to pick from (salvage, tip_off); any if empty
+}
+
+// MarketEventDef describes a planet-level economic event (loaded from YAML).
+// Events start either on a schedule (StartTick/RepeatEvery) or at random (Chance).
+type MarketEventDef struct {
+	Key      string   `yaml:"key" json:"key"`
+	Name     string   `yaml:"name" json:"name"`
+	Headline string   `yaml:"headline" json:"headline"` // "{planet}" is replaced with the planet name
+	Planets  []string `yaml:"planets" json:"planets"`   // Eligible planets (empty = any)
+	Items    []string `yaml:"items" json:"items"`       // Affected commodities (empty = all)
+
+	SourceHeatShift float64 `yaml:"source_heat_shift" json:"source_heat_shift"` // Heat held at 1.0 + shift while active
+	DestHeatShift   float64 `yaml:"dest_heat_shift" json:"dest_heat_shift"`
+	VolumeMult      float64 `yaml:"volume_mult" json:"volume_mult"` // Job board size multiplier (0 = unchanged)
+	Blocked         bool    `yaml:"blocked" json:"blocked"`         // No new jobs for Items (or the whole planet)
+
+	Duration    int64   `yaml:"duration" json:"duration"`         // Length in market ticks
+	Chance      float64 `yaml:"chance" json:"chance"`             // Per-tick odds of starting at random
+	StartTick   int64   `yaml:"start_tick" json:"start_tick"`     // Scheduled start (0 = unscheduled)
+	RepeatEvery int64   `yaml:"repeat_every" json:"repeat_every"` // Re-run a scheduled event every N ticks
+}
+
+// MarketEvent is a running instance of a MarketEventDef at a specific planet.
+type MarketEvent struct {
+	ID        string `yaml:"id" json:"id"`
+	Key       string `yaml:"key" json:"key"`
+	Name      string `yaml:"name" json:"name"`
+	Headline  string `yaml:"headline" json:"headline"`
+	PlanetKey string `yaml:"planet_key" json:"planet_key"`
+	StartTick int64  `yaml:"start_tick" json:"start_tick"`
+	EndTick   int64  `yaml:"end_tick" json:"end_tick"`
+	Status    string `yaml:"status" json:"status"` // "active" or "ended"
+}
+
 type Universe struct {
-	BalanceConfig   GameBalance     `yaml:"game_balance"`
-	ShipTemplates   []ShipTemplate  `yaml:"ship_templates"` // Changed from PlayerShipConfig
-	Commodities     []Commodity     `yaml:"commodities"`
-	Planets         []Planet        `yaml:"planets"`
-	ShipModules     []ShipModule    `yaml:"ship_modules"`
-	PassengerConfig PassengerConfig `yaml:"passenger_config"`
+	BalanceConfig   GameBalance       `yaml:"game_balance"`
+	ShipTemplates   []ShipTemplate    `yaml:"ship_templates"` // Changed from PlayerShipConfig
+	Commodities     []Commodity       `yaml:"commodities"`
+	Planets         []Planet          `yaml:"planets"`
+	ShipModules     []ShipModule      `yaml:"ship_modules"`
+	Recipes         []Recipe          `yaml:"recipes"`
+	MarketEvents    []MarketEventDef  `yaml:"market_events"`
+	NPCConfig       NPCConfig         `yaml:"npc_config"`
+	RivalConfig     RivalConfig       `yaml:"rival_config"`
+	Achievements    []Achievement     `yaml:"achievements"`
+	ArrivalEvents   []ArrivalEventDef `yaml:"arrival_events"`
+	PassengerConfig PassengerConfig   `yaml:"passenger_config"`
 }
 
 type MarketState struct {
 	SourceHeat map[string]map[string]float64
 	DestHeat   map[string]map[string]float64
+	Stockpile  map[string]map[string]int // [PlanetKey][ItemKey] -> Units held for production
+}
+
+// MarketSample is one snapshot of a commodity's market at a planet.
+type MarketSample struct {
+	Tick       int64   `yaml:"tick" json:"tick"`
+	SourceHeat float64 `yaml:"source_heat" json:"source_heat"`
+	DestHeat   float64 `yaml:"dest_heat" json:"dest_heat"`
+	UnitPayout int     `yaml:"unit_payout" json:"unit_payout"` // BaseValue adjusted by DestHeat
+}
+
+// PriceHistory is a fixed-size ring buffer of MarketSamples.
+type PriceHistory struct {
+	Samples []MarketSample `yaml:"samples" json:"samples"`
+	Next    int            `yaml:"next" json:"next"` // Slot the next sample overwrites once full
+}
+
+// NewsItem is a single timestamped headline.
+type NewsItem struct {
+	ID        int64     `yaml:"id" json:"id"`
+	Tick      int64     `yaml:"tick" json:"tick"`
+	Timestamp time.Time `yaml:"timestamp" json:"timestamp"`
+	Category  string    `yaml:"category" json:"category"` // "market", "event", "player"
+	PlanetKey string    `yaml:"planet_key" json:"planet_key"`
+	Headline  string    `yaml:"headline" json:"headline"`
 }
 
 // SaveData container for persistence
 type SaveData struct {
-	Player    Player                `yaml:"player"`
-	Market    MarketState           `yaml:"market"`
-	Contracts map[string][]Contract `yaml:"contracts"`
+	Player    Player                              `yaml:"player"`
+	Market    MarketState                         `yaml:"market"`
+	Contracts map[string][]Contract               `yaml:"contracts"`
+	Clock     int64                               `yaml:"clock"`
+	History   map[string]map[string]*PriceHistory `yaml:"history"`
+	Events    []MarketEvent                       `yaml:"market_events"`
+	News      []NewsItem                          `yaml:"news"`
+	NPCs      []*NPCTrader                        `yaml:"npcs"`
+	Rivals    []*NPCTrader                        `yaml:"rivals"`
+	AILevel   string                              `yaml:"ai_difficulty"`
+	Trips     []TripRecord                        `yaml:"trips"`
+	Ledger    []LedgerEntry                       `yaml:"ledger"`
+	Session   int                                 `yaml:"session"`
 }
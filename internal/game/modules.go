@@ -0,0 +1,317 @@
+/*
+Package game
+File: modules.go
+Description:
+    Data-driven ship modules.
+    A ship's stats are never edited directly: they are recomputed from its
+    template plus the effects of every installed module. This makes modules
+    removable and lets a single module touch several stats.
+    Uninstalled modules go into the player's ModuleStorage, from where they
+    can be refitted to any ship or sold back at a resale value.
+    Hulls carry typed hardpoints (engine, hold, cabin, utility) with sizes;
+    a module only fits a free slot of its type that is large enough.
+    All functions expect the caller (app.go) to hold DataLock.
+*/
+
+package game
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// Module slot types used in universe.yaml.
+const (
+	SlotEngine  = "engine"
+	SlotHold    = "hold"
+	SlotCabin   = "cabin"
+	SlotUtility = "utility"
+)
+
+// TemplateSlots returns the hull's hardpoints. Templates without typed slots
+// get MaxModuleSlots untyped slots, which accept any module.
+func TemplateSlots(t *ShipTemplate) []ModuleSlot {
+	if len(t.Slots) > 0 {
+		return append([]ModuleSlot{}, t.Slots...)
+	}
+	slots := make([]ModuleSlot, t.MaxModuleSlots)
+	for i := range slots {
+		slots[i] = ModuleSlot{Size: math.MaxInt32}
+	}
+	return slots
+}
+
+// ModuleSlotType returns the slot type a module fits, defaulting to utility.
+func ModuleSlotType(m *ShipModule) string {
+	if m.SlotType == "" {
+		return SlotUtility
+	}
+	return m.SlotType
+}
+
+// ModuleSize returns the module's size, defaulting to 1.
+func ModuleSize(m *ShipModule) int {
+	if m.Size <= 0 {
+		return 1
+	}
+	return m.Size
+}
+
+// slotFits reports whether a module can sit in a slot.
+func slotFits(slot ModuleSlot, m *ShipModule) bool {
+	if slot.Type != "" && slot.Type != ModuleSlotType(m) {
+		return false
+	}
+	return slot.Size >= ModuleSize(m)
+}
+
+// AssignSlots places every module in its own slot, largest modules first,
+// each taking the smallest free slot that fits. Returns the slot index per
+// module, or false if the modules cannot all be fitted.
+func AssignSlots(slots []ModuleSlot, modules []ShipModule) ([]int, bool) {
+	order := make([]int, len(modules))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return ModuleSize(&modules[order[a]]) > ModuleSize(&modules[order[b]])
+	})
+
+	used := make([]bool, len(slots))
+	assigned := make([]int, len(modules))
+	for _, mi := range order {
+		best := -1
+		for si, slot := range slots {
+			if used[si] || !slotFits(slot, &modules[mi]) {
+				continue
+			}
+			if best == -1 || slot.Size < slots[best].Size {
+				best = si
+			}
+		}
+		if best == -1 {
+			return nil, false
+		}
+		used[best] = true
+		assigned[mi] = best
+	}
+	return assigned, true
+}
+
+// ModuleEffects returns every effect of the module, including the legacy
+// StatModifier/StatValue pair as an additive effect.
+func ModuleEffects(m *ShipModule) []ModuleEffect {
+	effects := append([]ModuleEffect{}, m.Effects...)
+	if m.StatModifier != "" && m.StatValue != 0 {
+		effects = append(effects, ModuleEffect{Stat: m.StatModifier, Mode: "add", Value: float64(m.StatValue)})
+	}
+	return effects
+}
+
+// ModuleResaleValue is what an outfitter pays for a used module.
+func ModuleResaleValue(m *ShipModule) int {
+	return int(float64(m.Cost) * CurrentUniverse.BalanceConfig.ModuleResaleRate)
+}
+
+// RecomputeShipStats rebuilds a ship's stats from its template and modules.
+// Fuel is vented if the tank shrank below the current level. Modules that no
+// longer fit the hull's slots (e.g. from an older save) are moved to storage.
+func RecomputeShipStats(s *Ship) {
+	template := GetShipTemplate(s.TemplateKey)
+	if template == nil {
+		return // Unknown hull: leave the stored stats alone
+	}
+
+	// 0. Seat every module in a slot before counting its effects
+	s.Slots = TemplateSlots(template)
+	s.MaxModuleSlots = len(s.Slots)
+	fitModules(s)
+
+	// 1. Collect additive and multiplicative changes per stat
+	add := map[string]float64{}
+	mult := map[string]float64{}
+	for i := range s.InstalledModules {
+		for _, e := range ModuleEffects(&s.InstalledModules[i]) {
+			if e.Mode == "mult" {
+				if _, ok := mult[e.Stat]; !ok {
+					mult[e.Stat] = 1.0
+				}
+				mult[e.Stat] *= e.Value
+			} else {
+				add[e.Stat] += e.Value
+			}
+		}
+	}
+
+	apply := func(stat string, base float64) float64 {
+		v := base + add[stat]
+		if m, ok := mult[stat]; ok {
+			v *= m
+		}
+		return math.Round(v)
+	}
+
+	// 2. Template + effects, clamped to sane minimums
+	s.CargoCapacity = int(math.Max(0, apply("cargo_capacity", float64(template.CargoCapacity))))
+	s.PassengerSlots = int(math.Max(0, apply("passenger_slots", float64(template.PassengerSlots))))
+	s.MaxFuel = int64(math.Max(0, apply("max_fuel", float64(template.MaxFuel))))
+	s.BaseBurnRate = int64(math.Max(0, apply("base_burn_rate", float64(template.BaseBurnRate))))
+	s.BurnDamping = int64(math.Max(1, apply("burn_damping", float64(template.BurnDamping))))
+	s.BaseMass = int64(math.Max(1, apply("base_mass", float64(template.BaseMass))))
+	maxHull := int(math.Max(0, apply("max_hull", float64(template.MaxHull))))
+	if s.MaxHull == 0 {
+		s.Hull = maxHull // Saves from before hull tracking start undamaged
+	}
+	s.MaxHull = maxHull
+	if s.Hull > s.MaxHull {
+		s.Hull = s.MaxHull
+	}
+
+	if s.Fuel > s.MaxFuel {
+		s.Fuel = s.MaxFuel
+	}
+}
+
+// fitModules assigns each installed module a slot. If they cannot all be
+// seated, modules are kept in install order while they still fit and the
+// rest are moved to the player's storage.
+// Note: Caller must hold DataLock
+func fitModules(s *Ship) {
+	assigned, ok := AssignSlots(s.Slots, s.InstalledModules)
+	if !ok {
+		kept := []ShipModule{}
+		for _, m := range s.InstalledModules {
+			if _, fits := AssignSlots(s.Slots, append(append([]ShipModule{}, kept...), m)); fits {
+				kept = append(kept, m)
+				continue
+			}
+			m.Slot = -1
+			CurrentPlayer.ModuleStorage = append(CurrentPlayer.ModuleStorage, m)
+		}
+		s.InstalledModules = kept
+		assigned, _ = AssignSlots(s.Slots, kept)
+	}
+
+	for i := range s.InstalledModules {
+		s.InstalledModules[i].Slot = assigned[i]
+	}
+}
+
+// BuyModule purchases a module and installs it on the ship.
+// Note: Caller must hold DataLock
+func BuyModule(s *Ship, key string) error {
+	if !IsOutfitter(s.LocationKey) {
+		return errors.New("no outfitter at this location")
+	}
+
+	mod := GetModule(key)
+	if mod == nil || !StocksModule(s.LocationKey, key) {
+		return errors.New("module not sold here")
+	}
+	price := LocalPrice(s.LocationKey, mod.Cost)
+	if CurrentPlayer.Credits < price {
+		return errors.New("insufficient credits")
+	}
+	if err := installModule(s, *mod); err != nil {
+		return err
+	}
+
+	Transact(LedgerModulePurchase, -price, shipRef(s, "Bought "+mod.Name))
+	return nil
+}
+
+// UninstallModule removes an installed module into the player's storage.
+// Refused if the smaller ship could no longer hold its current contracts.
+// Note: Caller must hold DataLock
+func UninstallModule(s *Ship, index int) error {
+	if !IsOutfitter(s.LocationKey) {
+		return errors.New("no outfitter at this location")
+	}
+	if index < 0 || index >= len(s.InstalledModules) {
+		return errors.New("no module in that slot")
+	}
+
+	removed := s.InstalledModules[index]
+	remaining := append(append([]ShipModule{}, s.InstalledModules[:index]...), s.InstalledModules[index+1:]...)
+
+	// 1. Check capacity on a trial copy so a refusal leaves fuel and hull alone
+	trial := *s
+	trial.InstalledModules = remaining
+	RecomputeShipStats(&trial)
+	if !manifestFits(&trial) {
+		return errors.New("current contracts would no longer fit")
+	}
+
+	// 2. Commit
+	s.InstalledModules = remaining
+	RecomputeShipStats(s)
+	removed.Slot = -1
+	CurrentPlayer.ModuleStorage = append(CurrentPlayer.ModuleStorage, removed)
+	return nil
+}
+
+// InstallStoredModule refits a module from storage onto the ship.
+// Note: Caller must hold DataLock
+func InstallStoredModule(s *Ship, storageIndex int) error {
+	if !IsOutfitter(s.LocationKey) {
+		return errors.New("no outfitter at this location")
+	}
+	storage := CurrentPlayer.ModuleStorage
+	if storageIndex < 0 || storageIndex >= len(storage) {
+		return errors.New("no module in storage at that position")
+	}
+	if err := installModule(s, storage[storageIndex]); err != nil {
+		return err
+	}
+
+	CurrentPlayer.ModuleStorage = append(storage[:storageIndex], storage[storageIndex+1:]...)
+	return nil
+}
+
+// SellStoredModule sells a module from storage at its resale value.
+// Returns the credits received.
+// Note: Caller must hold DataLock
+func SellStoredModule(s *Ship, storageIndex int) (int, error) {
+	if !IsOutfitter(s.LocationKey) {
+		return 0, errors.New("no outfitter at this location")
+	}
+	storage := CurrentPlayer.ModuleStorage
+	if storageIndex < 0 || storageIndex >= len(storage) {
+		return 0, errors.New("no module in storage at that position")
+	}
+
+	value := ModuleResaleValue(&storage[storageIndex])
+	Transact(LedgerModuleSale, value, shipRef(s, "Sold "+storage[storageIndex].Name))
+	CurrentPlayer.ModuleStorage = append(storage[:storageIndex], storage[storageIndex+1:]...)
+	return value, nil
+}
+
+// installModule fits a module if a free slot of its type and size remains,
+// and recomputes stats.
+func installModule(s *Ship, mod ShipModule) error {
+	if len(s.InstalledModules) >= s.MaxModuleSlots {
+		return errors.New("no free module slots")
+	}
+	modules := append(append([]ShipModule{}, s.InstalledModules...), mod)
+	if _, ok := AssignSlots(s.Slots, modules); !ok {
+		return errors.New("no free " + ModuleSlotType(&mod) + " slot large enough")
+	}
+
+	s.InstalledModules = modules
+	RecomputeShipStats(s)
+	return nil
+}
+
+// manifestFits reports whether the ship's contracts fit its current capacity.
+func manifestFits(s *Ship) bool {
+	cargo, pax := 0, 0
+	for _, c := range s.ActiveContracts {
+		if c.Type == "cargo" {
+			cargo += c.Quantity
+		} else {
+			pax += c.Quantity
+		}
+	}
+	return cargo <= s.CargoCapacity && pax <= s.PassengerSlots
+}
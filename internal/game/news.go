@@ -0,0 +1,136 @@
+/*
+Package game
+File: news.go
+Description:
+    The sector news feed.
+    Other subsystems call PublishNews when something noteworthy happens
+    (market events, price swings, player milestones). Headlines are kept in a
+    capped feed that is saved with the game, and queued in an outbox that the
+    app drains to push `news_published` events to the UI.
+*/
+
+package game
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// maxNewsItems caps how many headlines the feed (and the save file) keeps.
+const maxNewsItems = 200
+
+// creditMilestoneStep is the balance interval announced as a player milestone.
+const creditMilestoneStep = 100000
+
+// newsOutbox holds headlines published since the last DrainNews call.
+// It is transient and never saved.
+var newsOutbox []NewsItem
+
+// PublishNews appends a headline to the feed and queues it for broadcast.
+// Note: Caller must hold DataLock
+func PublishNews(category, planetKey, headline string) {
+	var nextID int64 = 1
+	if n := len(NewsFeed); n > 0 {
+		nextID = NewsFeed[n-1].ID + 1
+	}
+
+	item := NewsItem{
+		ID:        nextID,
+		Tick:      GameClock,
+		Timestamp: time.Now(),
+		Category:  category,
+		PlanetKey: planetKey,
+		Headline:  headline,
+	}
+
+	NewsFeed = append(NewsFeed, item)
+	if len(NewsFeed) > maxNewsItems {
+		NewsFeed = NewsFeed[len(NewsFeed)-maxNewsItems:]
+	}
+	newsOutbox = append(newsOutbox, item)
+}
+
+// DrainNews returns every headline published since the previous call.
+func DrainNews() []NewsItem {
+	DataLock.Lock()
+	defer DataLock.Unlock()
+
+	drained := newsOutbox
+	newsOutbox = nil
+	return drained
+}
+
+// GetNews returns headlines with an ID greater than sinceID, oldest first.
+// Pass 0 to fetch the whole feed.
+// Note: Caller must hold DataLock
+func GetNews(sinceID int64) []NewsItem {
+	items := []NewsItem{}
+	for _, n := range NewsFeed {
+		if n.ID > sinceID {
+			items = append(items, n)
+		}
+	}
+	return items
+}
+
+// reportHeatSwings publishes a headline for any commodity whose price moved
+// sharply over the last market tick. At most one story is filed per planet.
+// Note: Caller must hold DataLock
+func reportHeatSwings() {
+	threshold := CurrentUniverse.BalanceConfig.NewsHeatSwing
+	if threshold <= 0 {
+		return
+	}
+
+	for _, p := range CurrentUniverse.Planets {
+		var (
+			bestItem  *Commodity
+			bestSwing float64
+		)
+		for i := range CurrentUniverse.Commodities {
+			c := &CurrentUniverse.Commodities[i]
+			series := MarketHistory[p.Key][c.Key]
+			if series == nil {
+				continue
+			}
+			recent := series.Ordered(2)
+			if len(recent) < 2 {
+				continue
+			}
+			swing := recent[1].DestHeat - recent[0].DestHeat
+			if math.Abs(swing) >= threshold && math.Abs(swing) > math.Abs(bestSwing) {
+				bestItem, bestSwing = c, swing
+			}
+		}
+
+		if bestItem == nil {
+			continue
+		}
+		// Rising destination heat means saturation, so payouts are falling.
+		if bestSwing > 0 {
+			PublishNews("market", p.Key, fmt.Sprintf("%s glut at %s sends delivery rates tumbling.", bestItem.Name, p.Name))
+		} else {
+			PublishNews("market", p.Key, fmt.Sprintf("Buyers at %s scramble for %s as rates climb.", p.Name, bestItem.Name))
+		}
+	}
+}
+
+// ReportPlayerArrival files player milestone stories after a delivery run.
+// Note: Caller must hold DataLock
+func ReportPlayerArrival(destKey string, payout, creditsBefore int) {
+	dest := GetPlanet(destKey)
+	if dest == nil {
+		return
+	}
+
+	if bigHaul := CurrentUniverse.BalanceConfig.NewsBigPayout; bigHaul > 0 && payout >= bigHaul {
+		PublishNews("player", destKey, fmt.Sprintf("%s lands a %d credit payday at %s.", CurrentPlayer.Name, payout, dest.Name))
+	}
+
+	before := creditsBefore / creditMilestoneStep
+	after := CurrentPlayer.Credits / creditMilestoneStep
+	if after > before {
+		PublishNews("player", destKey, fmt.Sprintf("%s's trading house passes %d credits.", CurrentPlayer.Name, after*creditMilestoneStep))
+	}
+}
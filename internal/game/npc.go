@@ -0,0 +1,278 @@
+/*
+Package game
+File: npc.go
+Description:
+    Simulates AI haulers that share the job boards with the player.
+    Each heartbeat, docked traders pick contracts according to their strategy,
+    refuel, and depart; traders in transit arrive once the game clock reaches
+    their ArrivalTick and deliver. Both sides call RecordAcceptance and
+    RecordDelivery, so NPC traffic moves market heat just like the player.
+    Named rival captains (rivals.go) run through the same simulation.
+*/
+
+package game
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// spawnNPCTraders creates the configured number of traders at random planets.
+// Note: Caller must hold DataLock
+func spawnNPCTraders() {
+	cfg := CurrentUniverse.NPCConfig
+	NPCTraders = []*NPCTrader{}
+
+	if len(CurrentUniverse.Planets) == 0 {
+		return
+	}
+
+	for i := 0; i < cfg.Count; i++ {
+		template := pickNPCTemplate(cfg.ShipTemplates)
+		if template == nil {
+			return
+		}
+
+		name := fmt.Sprintf("Trader %d", i+1)
+		if len(cfg.Names) > 0 {
+			name = cfg.Names[i%len(cfg.Names)]
+		}
+		strategy := "greedy"
+		if len(cfg.Strategies) > 0 {
+			strategy = cfg.Strategies[rand.Intn(len(cfg.Strategies))]
+		}
+
+		id := fmt.Sprintf("npc_%d", i+1)
+		home := CurrentUniverse.Planets[rand.Intn(len(CurrentUniverse.Planets))]
+
+		NPCTraders = append(NPCTraders, &NPCTrader{
+			ID:       id,
+			Name:     name,
+			Strategy: strategy,
+			Credits:  cfg.StartingCredits,
+			Ship:     NewShipFromTemplate(template, id+"_ship", name+"'s "+template.Name, home.Key),
+		})
+	}
+}
+
+// pickNPCTemplate chooses a ship template from the allowed keys (or any template).
+func pickNPCTemplate(keys []string) *ShipTemplate {
+	if len(keys) > 0 {
+		if t := GetShipTemplate(keys[rand.Intn(len(keys))]); t != nil {
+			return t
+		}
+	}
+	if len(CurrentUniverse.ShipTemplates) == 0 {
+		return nil
+	}
+	return &CurrentUniverse.ShipTemplates[rand.Intn(len(CurrentUniverse.ShipTemplates))]
+}
+
+// SimulateNPCs advances every AI trader and rival captain by one heartbeat.
+// Returns the planet keys whose job boards changed.
+func SimulateNPCs() []string {
+	DataLock.Lock()
+	defer DataLock.Unlock()
+
+	traders := make([]*NPCTrader, 0, len(NPCTraders)+len(Rivals))
+	traders = append(traders, NPCTraders...)
+	traders = append(traders, Rivals...)
+
+	updated := []string{}
+	for _, npc := range traders {
+		if npc.Ship == nil {
+			continue
+		}
+
+		// 1. In transit: arrive once the clock catches up
+		if npc.DestinationKey != "" {
+			if GameClock >= npc.ArrivalTick {
+				arriveNPC(npc)
+			}
+			continue
+		}
+
+		// 2. Docked: maybe look for work
+		chance := CurrentUniverse.NPCConfig.ActChance
+		if npc.ActChance > 0 {
+			chance = npc.ActChance
+		}
+		if chance > 0 && rand.Float64() >= chance {
+			continue
+		}
+		if loc := npc.Ship.LocationKey; dispatchNPC(npc) {
+			updated = appendUnique(updated, loc)
+		}
+	}
+	return updated
+}
+
+// arriveNPC docks a trader at its destination and delivers matching contracts.
+// Note: Caller must hold DataLock
+func arriveNPC(npc *NPCTrader) {
+	ship := npc.Ship
+	ship.LocationKey = npc.DestinationKey
+	npc.DestinationKey = ""
+
+	payout := 0
+	remaining := []Contract{}
+	for _, c := range ship.ActiveContracts {
+		if c.DestinationKey == ship.LocationKey {
+			payout += c.Payout
+			npc.ContractsDelivered++
+			Market.RecordDelivery(c.DestinationKey, c.ItemKey, c.Quantity)
+		} else {
+			remaining = append(remaining, c)
+		}
+	}
+	ship.ActiveContracts = remaining
+	npc.Credits += payout
+
+	reportRivalArrival(npc, payout)
+}
+
+// dispatchNPC takes contracts from the local board and departs.
+// Returns true if the trader took any contracts.
+// Note: Caller must hold DataLock
+func dispatchNPC(npc *NPCTrader) bool {
+	ship := npc.Ship
+	origin := GetPlanet(ship.LocationKey)
+	if origin == nil {
+		return false
+	}
+	board := AvailableContracts[origin.Key]
+
+	// 1. Choose the most attractive contract for this trader's strategy,
+	// ignoring routes longer than a full tank could ever cover.
+	burn := CalculateCurrentBurn(ship)
+	bestIdx, bestScore := -1, 0.0
+	for i, c := range board {
+		dest := GetPlanet(c.DestinationKey)
+		if dest == nil || CalculateDistance(origin.Coordinates, dest.Coordinates)*burn > ship.MaxFuel {
+			continue
+		}
+		score := scoreNPCContract(npc, origin, c)
+		if score > bestScore && CanCarry(ship, c) {
+			bestIdx, bestScore = i, score
+		}
+	}
+	if bestIdx == -1 {
+		return false
+	}
+	dest := GetPlanet(board[bestIdx].DestinationKey)
+	if dest == nil {
+		return false
+	}
+
+	// 2. Fill the hold with everything else headed the same way
+	taken := map[int]bool{}
+	for i, c := range board {
+		if c.DestinationKey != dest.Key || scoreNPCContract(npc, origin, c) <= 0 {
+			continue
+		}
+		if !CanCarry(ship, c) {
+			continue
+		}
+		ship.ActiveContracts = append(ship.ActiveContracts, c)
+		taken[i] = true
+	}
+
+	// 3. Top up the tank and check the trip is flyable
+	refuelNPC(npc)
+	dist := CalculateDistance(origin.Coordinates, dest.Coordinates)
+	cost := dist * CalculateCurrentBurn(ship)
+	if ship.Fuel < cost {
+		// Too heavy or too poor to fly: put the work back.
+		ship.ActiveContracts = ship.ActiveContracts[:len(ship.ActiveContracts)-len(taken)]
+		return false
+	}
+
+	// 4. Commit: remove from the board and move the market
+	remaining := []Contract{}
+	for i, c := range board {
+		if taken[i] {
+			Market.RecordAcceptance(c.OriginKey, c.ItemKey, c.Quantity)
+			continue
+		}
+		remaining = append(remaining, c)
+	}
+	AvailableContracts[origin.Key] = remaining
+
+	ship.Fuel -= cost
+	npc.DestinationKey = dest.Key
+	npc.ArrivalTick = GameClock + TravelTicks(dist)
+	return true
+}
+
+// scoreNPCContract rates a contract for a trader. Zero means "not interested".
+func scoreNPCContract(npc *NPCTrader, origin *Planet, c Contract) float64 {
+	dest := GetPlanet(c.DestinationKey)
+	if dest == nil {
+		return 0
+	}
+	dist := CalculateDistance(origin.Coordinates, dest.Coordinates)
+	if dist < 1 {
+		dist = 1
+	}
+
+	switch npc.Strategy {
+	case "local":
+		if reach := CurrentUniverse.NPCConfig.LocalRange; reach > 0 && dist > reach {
+			return 0
+		}
+		return float64(c.Payout)
+	case "passenger":
+		if c.Type != "passenger" {
+			return 0
+		}
+		return float64(c.Payout) / float64(dist)
+	default: // "greedy"
+		return float64(c.Payout) / float64(dist)
+	}
+}
+
+// refuelNPC fills the trader's tank as far as its credits allow.
+// Note: Caller must hold DataLock
+func refuelNPC(npc *NPCTrader) {
+	ship := npc.Ship
+	needed := AffordableFuel(ship.LocationKey, npc.Credits, ship.MaxFuel-ship.Fuel)
+	if needed <= 0 {
+		return
+	}
+
+	npc.Credits -= FuelCost(ship.LocationKey, needed)
+	ship.Fuel += needed
+}
+
+// TravelTicks converts a route length into game clock ticks for ships
+// simulated by the heartbeat (NPCs and automated fleet ships).
+func TravelTicks(dist int64) int64 {
+	speed := CurrentUniverse.NPCConfig.LYPerTick
+	if speed <= 0 {
+		return 1
+	}
+	ticks := (dist + speed - 1) / speed
+	if ticks < 1 {
+		ticks = 1
+	}
+	return ticks
+}
+
+// GetNPCTraders returns every simulated trader and where it is headed.
+// Note: Caller must hold DataLock
+func GetNPCTraders() []*NPCTrader {
+	if NPCTraders == nil {
+		return []*NPCTrader{}
+	}
+	return NPCTraders
+}
+
+// appendUnique appends key to list if it is not already present.
+func appendUnique(list []string, key string) []string {
+	for _, k := range list {
+		if k == key {
+			return list
+		}
+	}
+	return append(list, key)
+}
@@ -24,6 +24,16 @@ func SaveGame(filename string) error {
 		Player:    CurrentPlayer,
 		Market:    Market,
 		Contracts: AvailableContracts,
+		Clock:     GameClock,
+		History:   MarketHistory,
+		Events:    ActiveMarketEvents,
+		News:      NewsFeed,
+		NPCs:      NPCTraders,
+		Rivals:    Rivals,
+		AILevel:   AILevel,
+		Trips:     TripLog,
+		Ledger:    Ledger,
+		Session:   CurrentSession,
 	}
 
 	// 2. Marshal to YAML
@@ -58,6 +68,35 @@ func LoadGame(filename string) error {
 	CurrentPlayer = data.Player
 	Market = data.Market
 	AvailableContracts = data.Contracts
+	GameClock = data.Clock
+	MarketHistory = data.History
+	ActiveMarketEvents = data.Events
+	NewsFeed = data.News
+	newsOutbox = nil
+	NPCTraders = data.NPCs
+	Rivals = data.Rivals
+	AILevel = data.AILevel
+	TripLog = data.Trips
+	Ledger = data.Ledger
+	CurrentSession = data.Session + 1 // Each load starts a new play session
+
+	// Saves from before production chains carry no stockpile.
+	if Market.Stockpile == nil {
+		initStockpile()
+	}
+	if MarketHistory == nil {
+		MarketHistory = make(map[string]map[string]*PriceHistory)
+	}
+	if NPCTraders == nil {
+		spawnNPCTraders()
+	}
+
+	// Ship stats are derived from template + modules. Recomputing here also
+	// migrates older saves where modules permanently edited the stats, and
+	// moves modules that no longer fit the typed slots into storage.
+	for _, id := range sortedShipIDs() {
+		RecomputeShipStats(CurrentPlayer.Ships[id])
+	}
 
 	// Note:
 	// Fields tagged with `yaml:"-"` (TotalMass, CurrentBurn) are not saved.
@@ -0,0 +1,145 @@
+/*
+Package game
+File: production.go
+Description:
+    Handles the manufacturing side of the economy.
+    This includes:
+    1. Stockpiling recipe inputs delivered to a planet.
+    2. Running each planet's recipes once per market tick.
+    3. Drawing manufactured goods out of the stockpile when contracts are posted.
+
+    Goods that a planet lists in Production but does not manufacture through a
+    recipe (e.g. Ore at Outpost Alpha) are treated as raw and never run out.
+*/
+
+package game
+
+// initStockpile seeds the production stockpile for every planet.
+// Each recipe output starts with the recipe's StartingStock so factories
+// have something to ship before the first inputs arrive.
+func initStockpile() {
+	Market.Stockpile = make(map[string]map[string]int)
+	for _, p := range CurrentUniverse.Planets {
+		Market.Stockpile[p.Key] = make(map[string]int)
+
+		for _, rKey := range p.Recipes {
+			recipe := GetRecipe(rKey)
+			if recipe == nil {
+				continue
+			}
+			for _, out := range recipe.Outputs {
+				Market.Stockpile[p.Key][out.ItemKey] += recipe.StartingStock
+			}
+		}
+	}
+}
+
+// IsManufactured reports whether the planet makes itemKey through a recipe.
+// Manufactured goods are limited by the stockpile instead of being free.
+func IsManufactured(p *Planet, itemKey string) bool {
+	for _, rKey := range p.Recipes {
+		recipe := GetRecipe(rKey)
+		if recipe == nil {
+			continue
+		}
+		for _, out := range recipe.Outputs {
+			if out.ItemKey == itemKey {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsRecipeInput reports whether any recipe at the planet consumes itemKey.
+func IsRecipeInput(p *Planet, itemKey string) bool {
+	for _, rKey := range p.Recipes {
+		recipe := GetRecipe(rKey)
+		if recipe == nil {
+			continue
+		}
+		for _, in := range recipe.Inputs {
+			if in.ItemKey == itemKey {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// addStock adds units to a planet stockpile, respecting the global cap.
+// Note: Caller must hold DataLock
+func (m *MarketState) addStock(planetKey, itemKey string, qty int) {
+	if m.Stockpile == nil {
+		return
+	}
+	if m.Stockpile[planetKey] == nil {
+		m.Stockpile[planetKey] = make(map[string]int)
+	}
+
+	m.Stockpile[planetKey][itemKey] += qty
+
+	limit := CurrentUniverse.BalanceConfig.StockpileCap
+	if limit > 0 && m.Stockpile[planetKey][itemKey] > limit {
+		m.Stockpile[planetKey][itemKey] = limit
+	}
+}
+
+// takeStock removes qty units from a planet stockpile.
+// Returns false (and removes nothing) if there is not enough on hand.
+// Note: Caller must hold DataLock
+func (m *MarketState) takeStock(planetKey, itemKey string, qty int) bool {
+	if m.Stockpile[planetKey][itemKey] < qty {
+		return false
+	}
+	m.Stockpile[planetKey][itemKey] -= qty
+	return true
+}
+
+// runProduction executes every planet's recipes for one market tick.
+// A recipe runs as many batches as its inputs allow, up to CyclesPerTick.
+// Note: Caller must hold DataLock
+func runProduction() {
+	if Market.Stockpile == nil {
+		return
+	}
+
+	for _, p := range CurrentUniverse.Planets {
+		stock := Market.Stockpile[p.Key]
+		if stock == nil {
+			continue
+		}
+
+		for _, rKey := range p.Recipes {
+			recipe := GetRecipe(rKey)
+			if recipe == nil || len(recipe.Inputs) == 0 {
+				continue
+			}
+
+			// 1. How many batches can the delivered inputs support?
+			cycles := recipe.CyclesPerTick
+			if cycles <= 0 {
+				cycles = 1
+			}
+			for _, in := range recipe.Inputs {
+				if in.Quantity <= 0 {
+					continue
+				}
+				if possible := stock[in.ItemKey] / in.Quantity; possible < cycles {
+					cycles = possible
+				}
+			}
+			if cycles == 0 {
+				continue // Starved
+			}
+
+			// 2. Consume inputs, produce outputs
+			for _, in := range recipe.Inputs {
+				stock[in.ItemKey] -= in.Quantity * cycles
+			}
+			for _, out := range recipe.Outputs {
+				Market.addStock(p.Key, out.ItemKey, out.Quantity*cycles)
+			}
+		}
+	}
+}
@@ -0,0 +1,329 @@
+/*
+Package game
+File: reports.go
+Description:
+    Profitability reports for the dashboard.
+    Built from the ledger (money) and the trip log (distance and fuel):
+    1. Overall earnings per light-year.
+    2. Per route: fuel spend against payout.
+    3. Per ship: revenue, expenses and net.
+    4. Per commodity: revenue and average payout, best first.
+    5. Per play session: income, expenses and net.
+    Every ledger type falls in exactly one class (revenue, expense,
+    financing or capital), and every report uses the same classes, so
+    "Net" always means operating revenue minus operating expenses.
+    Reports cover the history still kept in the capped ledger and trip log.
+*/
+
+package game
+
+import (
+	"sort"
+	"time"
+)
+
+// ProfitSummary is the headline profitability across all retained history.
+type ProfitSummary struct {
+	Revenue        int     `json:"revenue"`   // Payouts, claims and windfalls
+	Expenses       int     `json:"expenses"`  // Operating costs (positive number)
+	Net            int     `json:"net"`       // Revenue - Expenses
+	Financing      int     `json:"financing"` // Net borrowing: loans and opening balance less repayments
+	Capital        int     `json:"capital"`   // Net asset sales less purchases (ships, modules)
+	Trips          int     `json:"trips"`
+	Distance       int64   `json:"distance"`
+	EarningsPerLY  float64 `json:"earnings_per_ly"` // Trip payouts per LY flown
+	BestCommodity  string  `json:"best_commodity"`
+	WorstCommodity string  `json:"worst_commodity"`
+}
+
+// RouteReport aggregates every trip between two planets (in that direction).
+type RouteReport struct {
+	OriginKey      string  `json:"origin_key"`
+	DestinationKey string  `json:"destination_key"`
+	Trips          int     `json:"trips"`
+	Distance       int64   `json:"distance"` // Total LY flown on the route
+	FuelCost       int     `json:"fuel_cost"`
+	Payout         int     `json:"payout"`
+	Profit         int     `json:"profit"` // Payout - FuelCost
+	EarningsPerLY  float64 `json:"earnings_per_ly"`
+}
+
+// ShipReport aggregates the ledger and trip log for one ship.
+type ShipReport struct {
+	ShipID   string `json:"ship_id"`
+	ShipName string `json:"ship_name"`
+	Owned    bool   `json:"owned"` // Still in Player.Ships
+	Revenue  int    `json:"revenue"`
+	Expenses int    `json:"expenses"`
+	Net      int    `json:"net"`
+	Trips    int    `json:"trips"`
+	Distance int64  `json:"distance"`
+}
+
+// CommodityReport aggregates contract payouts for one commodity.
+type CommodityReport struct {
+	ItemKey    string `json:"item_key"`
+	Name       string `json:"name"`
+	Deliveries int    `json:"deliveries"`
+	Revenue    int    `json:"revenue"`
+	AvgPayout  int    `json:"avg_payout"`
+	Claims     int    `json:"claims"` // Insurance paid for lost contracts
+}
+
+// SessionReport summarises one play session.
+type SessionReport struct {
+	Session    int       `json:"session"`
+	Start      time.Time `json:"start"`
+	End        time.Time `json:"end"`
+	Income     int       `json:"income"`
+	Expenses   int       `json:"expenses"`
+	Net        int       `json:"net"`
+	Deliveries int       `json:"deliveries"`
+}
+
+// Reports bundles every dashboard report.
+type Reports struct {
+	Summary     ProfitSummary     `json:"summary"`
+	Routes      []RouteReport     `json:"routes"`
+	Ships       []ShipReport      `json:"ships"`
+	Commodities []CommodityReport `json:"commodities"`
+	Sessions    []SessionReport   `json:"sessions"`
+}
+
+// Ledger classes used by every report.
+const (
+	classRevenue   = "revenue"   // Earned by operating: payouts, claims, windfalls
+	classExpense   = "expense"   // Spent operating: fuel, upkeep, premiums, tows, fines
+	classFinancing = "financing" // Borrowing (loans, tows on credit) and paying it back, and the opening balance
+	classCapital   = "capital"   // Buying and selling ships and modules
+)
+
+// ledgerClass puts a ledger entry in exactly one report class.
+func ledgerClass(e LedgerEntry) string {
+	switch e.Type {
+	case LedgerPayout, LedgerClaim:
+		return classRevenue
+	case LedgerOpening, LedgerLoan, LedgerLoanRepayment, LedgerTowCredit, LedgerDebtRepayment:
+		return classFinancing
+	case LedgerShipPurchase, LedgerShipSale, LedgerModulePurchase, LedgerModuleSale, LedgerForeclosure:
+		return classCapital
+	case LedgerTravelEvent:
+		if e.Amount > 0 {
+			return classRevenue
+		}
+		return classExpense
+	default:
+		return classExpense
+	}
+}
+
+// isRevenue reports whether a ledger entry counts as operating revenue.
+func isRevenue(e LedgerEntry) bool {
+	return ledgerClass(e) == classRevenue
+}
+
+// isExpense reports whether a ledger entry counts as an operating expense.
+func isExpense(e LedgerEntry) bool {
+	return ledgerClass(e) == classExpense
+}
+
+// GetReports builds every report from the ledger and trip log.
+// Note: Caller must hold DataLock
+func GetReports() Reports {
+	r := Reports{
+		Routes:      buildRouteReports(),
+		Ships:       buildShipReports(),
+		Commodities: buildCommodityReports(),
+		Sessions:    buildSessionReports(),
+	}
+
+	// Summary
+	for _, e := range Ledger {
+		switch ledgerClass(e) {
+		case classRevenue:
+			r.Summary.Revenue += e.Amount
+		case classExpense:
+			r.Summary.Expenses -= e.Amount
+		case classFinancing:
+			r.Summary.Financing += e.Amount
+		case classCapital:
+			r.Summary.Capital += e.Amount
+		}
+	}
+	r.Summary.Net = r.Summary.Revenue - r.Summary.Expenses
+
+	payout := 0
+	for _, t := range TripLog {
+		r.Summary.Trips++
+		r.Summary.Distance += t.Distance
+		payout += t.Payout
+	}
+	r.Summary.EarningsPerLY = perLY(payout, r.Summary.Distance)
+
+	if n := len(r.Commodities); n > 0 {
+		r.Summary.BestCommodity = r.Commodities[0].ItemKey
+		r.Summary.WorstCommodity = r.Commodities[n-1].ItemKey
+	}
+	return r
+}
+
+// buildRouteReports groups the trip log by origin and destination, most profitable first.
+func buildRouteReports() []RouteReport {
+	index := map[[2]string]int{}
+	routes := []RouteReport{}
+	for _, t := range TripLog {
+		key := [2]string{t.OriginKey, t.DestinationKey}
+		i, ok := index[key]
+		if !ok {
+			i = len(routes)
+			index[key] = i
+			routes = append(routes, RouteReport{OriginKey: t.OriginKey, DestinationKey: t.DestinationKey})
+		}
+		r := &routes[i]
+		r.Trips++
+		r.Distance += t.Distance
+		r.FuelCost += t.FuelCost
+		r.Payout += t.Payout
+	}
+
+	for i := range routes {
+		routes[i].Profit = routes[i].Payout - routes[i].FuelCost
+		routes[i].EarningsPerLY = perLY(routes[i].Payout, routes[i].Distance)
+	}
+	sort.SliceStable(routes, func(i, j int) bool { return routes[i].Profit > routes[j].Profit })
+	return routes
+}
+
+// buildShipReports totals the ledger and trip log per ship, best earner first.
+func buildShipReports() []ShipReport {
+	index := map[string]int{}
+	ships := []ShipReport{}
+	get := func(id, name string) *ShipReport {
+		i, ok := index[id]
+		if !ok {
+			i = len(ships)
+			index[id] = i
+			ships = append(ships, ShipReport{ShipID: id, ShipName: name})
+		}
+		if name != "" {
+			ships[i].ShipName = name
+		}
+		return &ships[i]
+	}
+
+	for _, id := range sortedShipIDs() {
+		get(id, CurrentPlayer.Ships[id].Name)
+	}
+	for _, t := range TripLog {
+		s := get(t.ShipID, "")
+		if s.ShipName == "" {
+			s.ShipName = t.ShipName
+		}
+		s.Trips++
+		s.Distance += t.Distance
+	}
+	for _, e := range Ledger {
+		if e.ShipID == "" || !(isRevenue(e) || isExpense(e)) {
+			continue
+		}
+		s := get(e.ShipID, "")
+		if isRevenue(e) {
+			s.Revenue += e.Amount
+		} else {
+			s.Expenses -= e.Amount
+		}
+	}
+
+	for i := range ships {
+		_, ships[i].Owned = CurrentPlayer.Ships[ships[i].ShipID]
+		ships[i].Net = ships[i].Revenue - ships[i].Expenses
+	}
+	sort.SliceStable(ships, func(i, j int) bool { return ships[i].Net > ships[j].Net })
+	return ships
+}
+
+// buildCommodityReports totals contract payouts per commodity, best average first.
+func buildCommodityReports() []CommodityReport {
+	index := map[string]int{}
+	items := []CommodityReport{}
+	for _, e := range Ledger {
+		if e.ItemKey == "" || (e.Type != LedgerPayout && e.Type != LedgerClaim) {
+			continue
+		}
+		i, ok := index[e.ItemKey]
+		if !ok {
+			i = len(items)
+			index[e.ItemKey] = i
+			name := e.ItemKey
+			if c := GetCommodity(e.ItemKey); c != nil {
+				name = c.Name
+			} else if e.ItemKey == "passenger" {
+				name = "Passengers"
+			}
+			items = append(items, CommodityReport{ItemKey: e.ItemKey, Name: name})
+		}
+		if e.Type == LedgerClaim {
+			items[i].Claims += e.Amount
+			continue
+		}
+		items[i].Deliveries++
+		items[i].Revenue += e.Amount
+	}
+
+	for i := range items {
+		if items[i].Deliveries > 0 {
+			items[i].AvgPayout = items[i].Revenue / items[i].Deliveries
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].AvgPayout > items[j].AvgPayout })
+	return items
+}
+
+// buildSessionReports totals the ledger per play session, oldest first.
+func buildSessionReports() []SessionReport {
+	index := map[int]int{}
+	sessions := []SessionReport{}
+	for _, e := range Ledger {
+		i, ok := index[e.Session]
+		if !ok {
+			i = len(sessions)
+			index[e.Session] = i
+			sessions = append(sessions, SessionReport{Session: e.Session, Start: e.Timestamp})
+		}
+		s := &sessions[i]
+		s.End = e.Timestamp
+		switch {
+		case isRevenue(e):
+			s.Income += e.Amount
+		case isExpense(e):
+			s.Expenses -= e.Amount
+		}
+		if e.Type == LedgerPayout {
+			s.Deliveries++
+		}
+	}
+
+	for i := range sessions {
+		sessions[i].Net = sessions[i].Income - sessions[i].Expenses
+	}
+	sort.SliceStable(sessions, func(i, j int) bool { return sessions[i].Session < sessions[j].Session })
+	return sessions
+}
+
+// sortedShipIDs lists the player's ship IDs in a stable order.
+func sortedShipIDs() []string {
+	ids := make([]string, 0, len(CurrentPlayer.Ships))
+	for id := range CurrentPlayer.Ships {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// perLY divides credits by distance, guarding against zero.
+func perLY(credits int, dist int64) float64 {
+	if dist <= 0 {
+		return 0
+	}
+	return float64(credits) / float64(dist)
+}
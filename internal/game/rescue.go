@@ -0,0 +1,274 @@
+/*
+Package game
+File: rescue.go
+Description:
+    Stranded ships, emergency tows and bankruptcy.
+    A ship is stranded when it cannot reach any other planet even after
+    buying all the fuel the player can afford. The captain can then call a
+    tow to the nearest fuel depot; whatever the player cannot pay becomes
+    debt, garnished from later payouts.
+    The player is bankrupt, and the game over, only when the active ship is
+    stranded, a tow would push the debt past MaxDebt even after selling
+    every ship and module that can be sold, and no other ship in the fleet
+    can still fly to the rescue. A bankrupt player can take no further
+    actions until a new game is started or a save is loaded.
+*/
+
+package game
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// RescueStatus summarises the active ship's predicament for the UI.
+type RescueStatus struct {
+	Stranded bool   `json:"stranded"`
+	DepotKey string `json:"depot_key"` // Where a tow would take the ship
+	Distance int64  `json:"distance"`
+	TowCost  int    `json:"tow_cost"` // Towing fee plus the emergency fuel
+	Debt     int    `json:"debt"`
+	CanTow   bool   `json:"can_tow"` // False means a tow would exceed MaxDebt
+	Assets   int    `json:"assets"`  // Credits that selling ships and stored modules would raise
+	// Recoverable is false when nothing can save the player: no tow within
+	// credit even after selling assets, and no other ship able to fly.
+	Recoverable bool `json:"recoverable"`
+	Bankrupt    bool `json:"bankrupt"`
+}
+
+// IsStranded reports whether the ship cannot reach any other planet,
+// even after spending the player's credits on fuel where it is.
+// Ships in flight are never stranded.
+func IsStranded(s *Ship) bool {
+	if s.Voyage != nil {
+		return false
+	}
+	curr := GetPlanet(s.LocationKey)
+	if curr == nil {
+		return false
+	}
+
+	fuel := s.Fuel + AffordableFuel(s.LocationKey, CurrentPlayer.Credits, s.MaxFuel-s.Fuel)
+	burn := CalculateCurrentBurn(s)
+	for _, p := range CurrentUniverse.Planets {
+		if p.Key != curr.Key && CalculateDistance(curr.Coordinates, p.Coordinates)*burn <= fuel {
+			return false
+		}
+	}
+	return true
+}
+
+// NearestFuelDepot returns the closest planet with a fuel depot
+// (possibly the current one) and its distance.
+func NearestFuelDepot(fromKey string) (*Planet, int64) {
+	from := GetPlanet(fromKey)
+	if from == nil {
+		return nil, 0
+	}
+
+	var best *Planet
+	bestDist := int64(math.MaxInt64)
+	for i := range CurrentUniverse.Planets {
+		p := &CurrentUniverse.Planets[i]
+		if !HasService(p.Key, ServiceFuelDepot) {
+			continue
+		}
+		if d := CalculateDistance(from.Coordinates, p.Coordinates); d < bestDist {
+			best, bestDist = p, d
+		}
+	}
+	return best, bestDist
+}
+
+// TowCost prices towing the ship 'dist' LY to depotKey, including the
+// emergency fuel the tug leaves in the tank.
+func TowCost(s *Ship, depotKey string, dist int64) int {
+	cfg := CurrentUniverse.BalanceConfig
+	return cfg.TowBaseFee + int(dist)*cfg.TowFeePerLY + FuelCost(depotKey, towFuel(s, depotKey))
+}
+
+// towFuel is how much fuel the tug leaves the ship with at the depot: the
+// TowFuelShare of the tank, or enough to reach the depot's nearest neighbour.
+func towFuel(s *Ship, depotKey string) int64 {
+	target := int64(float64(s.MaxFuel) * CurrentUniverse.BalanceConfig.TowFuelShare)
+	if depot := GetPlanet(depotKey); depot != nil {
+		hop := int64(math.MaxInt64)
+		for _, p := range CurrentUniverse.Planets {
+			if p.Key != depot.Key {
+				hop = min(hop, CalculateDistance(depot.Coordinates, p.Coordinates))
+			}
+		}
+		if hop != math.MaxInt64 {
+			// Burn depends on fuel mass, so settle the estimate on a trial copy
+			trial := *s
+			for i := 0; i < 20 && trial.Fuel < s.MaxFuel; i++ {
+				need := hop * CalculateCurrentBurn(&trial)
+				if need <= trial.Fuel {
+					break
+				}
+				trial.Fuel = min(s.MaxFuel, need)
+			}
+			target = max(target, trial.Fuel)
+		}
+	}
+	if target <= s.Fuel {
+		return 0
+	}
+	return target - s.Fuel
+}
+
+// GetRescueStatus reports whether the ship is stranded and what a tow would cost.
+// Note: Caller must hold DataLock
+func GetRescueStatus(s *Ship) RescueStatus {
+	status := RescueStatus{
+		Stranded: IsStranded(s),
+		Debt:     CurrentPlayer.Debt,
+		Bankrupt: CurrentPlayer.Bankrupt,
+	}
+	if depot, dist := NearestFuelDepot(s.LocationKey); depot != nil {
+		status.DepotKey = depot.Key
+		status.Distance = dist
+		status.TowCost = TowCost(s, depot.Key, dist)
+		status.CanTow = towShortfall(status.TowCost) <= CurrentUniverse.BalanceConfig.MaxDebt
+	}
+	status.Assets = SellableAssets(s)
+	status.Recoverable = !status.Stranded || status.CanTow || spareShip(s) ||
+		(status.DepotKey != "" && towShortfall(status.TowCost-status.Assets) <= CurrentUniverse.BalanceConfig.MaxDebt)
+	return status
+}
+
+// CheckBankruptcy declares the player bankrupt when the ship is stranded
+// and GetRescueStatus finds no way out.
+// Note: Caller must hold DataLock
+func CheckBankruptcy(s *Ship) RescueStatus {
+	status := GetRescueStatus(s)
+	if !status.Recoverable && !CurrentPlayer.Bankrupt {
+		CurrentPlayer.Bankrupt = true
+		status.Bankrupt = true
+	}
+	return status
+}
+
+// SellableAssets is what the player could raise right now by selling fleet
+// ships docked at a shipyard with empty holds (as SellShip allows) and, if
+// the ship is at an outfitter, the modules in storage.
+func SellableAssets(s *Ship) int {
+	total := 0
+	for id, other := range CurrentPlayer.Ships {
+		if id == CurrentPlayer.ActiveShipKey || other.Voyage != nil {
+			continue
+		}
+		if IsShipyard(other.LocationKey) && len(other.ActiveContracts) == 0 {
+			total += ShipResaleValue(other)
+		}
+	}
+	if IsOutfitter(s.LocationKey) {
+		for i := range CurrentPlayer.ModuleStorage {
+			total += ModuleResaleValue(&CurrentPlayer.ModuleStorage[i])
+		}
+	}
+	return total
+}
+
+// spareShip reports whether another ship in the fleet is in flight or can
+// still fly somewhere, and so could earn credits or bring fuel.
+func spareShip(s *Ship) bool {
+	for _, other := range CurrentPlayer.Ships {
+		if other == s {
+			continue
+		}
+		if other.Voyage != nil || !IsStranded(other) {
+			return true
+		}
+	}
+	return false
+}
+
+// towShortfall is the debt the player would owe after paying 'cost' from credits.
+func towShortfall(cost int) int {
+	unpaid := cost - CurrentPlayer.Credits
+	if unpaid < 0 {
+		unpaid = 0
+	}
+	return CurrentPlayer.Debt + unpaid
+}
+
+// TowToDepot rescues a stranded ship: it is moved to the nearest fuel depot
+// and refuelled, paying what it can and borrowing the rest. If the debt
+// would exceed MaxDebt the tow is refused, and the player is declared
+// bankrupt unless selling assets or another ship can still save them.
+// Note: Caller must hold DataLock
+func TowToDepot(s *Ship) (RescueStatus, error) {
+	if CurrentPlayer.Bankrupt {
+		return GetRescueStatus(s), errors.New("bankrupt")
+	}
+	if !IsStranded(s) {
+		return GetRescueStatus(s), errors.New("ship is not stranded")
+	}
+
+	depot, dist := NearestFuelDepot(s.LocationKey)
+	if depot == nil {
+		return CheckBankruptcy(s), errors.New("no fuel depot can reach you")
+	}
+
+	cost := TowCost(s, depot.Key, dist)
+	debt := towShortfall(cost)
+	if debt > CurrentUniverse.BalanceConfig.MaxDebt {
+		status := CheckBankruptcy(s)
+		if status.Bankrupt {
+			return status, errors.New("rescue would exceed your credit: bankrupt")
+		}
+		return status, errors.New("rescue would exceed your credit: sell ships or modules first")
+	}
+
+	// 1. Pay what we can, the rest goes on the tab. The whole fee is booked
+	// as the tow and the borrowed part as financing, so reports see the
+	// full cost whether or not it was paid in cash.
+	paid := cost
+	if paid > CurrentPlayer.Credits {
+		paid = CurrentPlayer.Credits
+	}
+	Transact(LedgerTow, -cost, shipRef(s, fmt.Sprintf("Tow to %s (%d on credit)", depot.Name, cost-paid)))
+	Transact(LedgerTowCredit, cost-paid, shipRef(s, "Tow put on credit"))
+	CurrentPlayer.Debt = debt
+
+	// 2. Haul the ship in and leave it with emergency fuel
+	s.Fuel += towFuel(s, depot.Key)
+	s.LocationKey = depot.Key
+	return GetRescueStatus(s), nil
+}
+
+// GarnishPayout takes the DebtGarnishRate share of a payout just received
+// towards the player's debt. Returns the amount taken.
+// Note: Caller must hold DataLock
+func GarnishPayout(s *Ship, payout int) int {
+	if CurrentPlayer.Debt <= 0 || payout <= 0 {
+		return 0
+	}
+	taken := int(math.Ceil(float64(payout) * CurrentUniverse.BalanceConfig.DebtGarnishRate))
+	if taken > CurrentPlayer.Debt {
+		taken = CurrentPlayer.Debt
+	}
+	CurrentPlayer.Debt -= taken
+	Transact(LedgerDebtRepayment, -taken, shipRef(s, "Garnished from payout"))
+	return taken
+}
+
+// RepayDebt pays down debt from credits. Returns the amount repaid.
+// Note: Caller must hold DataLock
+func RepayDebt(amount int) (int, error) {
+	if CurrentPlayer.Debt <= 0 {
+		return 0, errors.New("no debt to repay")
+	}
+	if amount <= 0 || amount > CurrentPlayer.Debt {
+		amount = CurrentPlayer.Debt
+	}
+	if amount > CurrentPlayer.Credits {
+		return 0, errors.New("insufficient credits")
+	}
+
+	Transact(LedgerDebtRepayment, -amount, LedgerRef{Description: "Debt repayment"})
+	CurrentPlayer.Debt -= amount
+	return amount, nil
+}
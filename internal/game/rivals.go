@@ -0,0 +1,140 @@
+/*
+Package game
+File: rivals.go
+Description:
+    Named rival captains.
+    Rivals are NPCTraders with a name, a personality and a save-persistent
+    career. They fly the same simulation as anonymous NPC traffic (npc.go),
+    but their strength is set by the AI difficulty chosen for a new game and
+    their progress is published as a standings table and in the news.
+*/
+
+package game
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+// InitializeRivals creates the rival captains for a new game.
+// An empty difficultyKey uses the configured default.
+func InitializeRivals(difficultyKey string) error {
+	DataLock.Lock()
+	defer DataLock.Unlock()
+
+	cfg := CurrentUniverse.RivalConfig
+	if difficultyKey == "" {
+		difficultyKey = cfg.DefaultDifficulty
+	}
+	level := GetAIDifficulty(difficultyKey)
+	if level == nil {
+		return errors.New("unknown AI difficulty: " + difficultyKey)
+	}
+
+	AILevel = level.Key
+	Rivals = []*NPCTrader{}
+
+	for _, captain := range cfg.Captains {
+		template := GetShipTemplate(captain.ShipTemplate)
+		if template == nil {
+			return errors.New("rival " + captain.Name + " uses unknown ship template: " + captain.ShipTemplate)
+		}
+
+		home := GetPlanet(captain.HomeKey)
+		if home == nil && len(CurrentUniverse.Planets) > 0 {
+			home = &CurrentUniverse.Planets[rand.Intn(len(CurrentUniverse.Planets))]
+		}
+		if home == nil {
+			return errors.New("no planets to place rivals")
+		}
+
+		Rivals = append(Rivals, &NPCTrader{
+			ID:          captain.Key,
+			Name:        captain.Name,
+			Strategy:    personalityStrategy(captain.Personality),
+			Personality: captain.Personality,
+			Credits:     level.StartingCredits,
+			ActChance:   level.ActChance,
+			Ship:        NewShipFromTemplate(template, captain.Key+"_ship", template.Name, home.Key),
+		})
+	}
+
+	return nil
+}
+
+// personalityStrategy maps a rival personality onto an NPC trading strategy.
+func personalityStrategy(personality string) string {
+	switch personality {
+	case "cautious":
+		return "local"
+	case "passenger":
+		return "passenger"
+	default: // "aggressive"
+		return "greedy"
+	}
+}
+
+// GetAIDifficulty is a helper to retrieve an AIDifficulty pointer by its Key.
+func GetAIDifficulty(key string) *AIDifficulty {
+	for _, d := range CurrentUniverse.RivalConfig.Difficulties {
+		if d.Key == key {
+			return &d
+		}
+	}
+	return nil
+}
+
+// reportRivalArrival files a story when a rival lands a notable payday.
+// Note: Caller must hold DataLock
+func reportRivalArrival(npc *NPCTrader, payout int) {
+	threshold := CurrentUniverse.BalanceConfig.NewsRivalHaul
+	if npc.Personality == "" || threshold <= 0 || payout < threshold {
+		return
+	}
+	if p := GetPlanet(npc.Ship.LocationKey); p != nil {
+		PublishNews("rival", p.Key, fmt.Sprintf("Rival %s cashes in %d credits at %s.", npc.Name, payout, p.Name))
+	}
+}
+
+// GetRivalStandings ranks the player and every rival captain by credits.
+// Note: Caller must hold DataLock
+func GetRivalStandings() []RivalStanding {
+	standings := []RivalStanding{}
+
+	if ship := CurrentPlayer.Ships[CurrentPlayer.ActiveShipKey]; ship != nil {
+		standings = append(standings, RivalStanding{
+			Name:            CurrentPlayer.Name,
+			IsPlayer:        true,
+			Credits:         CurrentPlayer.Credits,
+			ShipName:        ship.Name,
+			ShipTemplateKey: ship.TemplateKey,
+			LocationKey:     ship.LocationKey,
+		})
+	}
+
+	for _, r := range Rivals {
+		if r.Ship == nil {
+			continue
+		}
+		standings = append(standings, RivalStanding{
+			Name:               r.Name,
+			Personality:        r.Personality,
+			Credits:            r.Credits,
+			ShipName:           r.Ship.Name,
+			ShipTemplateKey:    r.Ship.TemplateKey,
+			LocationKey:        r.Ship.LocationKey,
+			DestinationKey:     r.DestinationKey,
+			ContractsDelivered: r.ContractsDelivered,
+		})
+	}
+
+	sort.SliceStable(standings, func(i, j int) bool {
+		return standings[i].Credits > standings[j].Credits
+	})
+	for i := range standings {
+		standings[i].Rank = i + 1
+	}
+	return standings
+}
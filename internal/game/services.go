@@ -0,0 +1,113 @@
+/*
+Package game
+File: services.go
+Description:
+    Planet services (shipyard, fuel depot, outfitting, black market, bank).
+    Planets declare their services and shipyard stock in universe.yaml.
+    These helpers answer "can I do X here?" and "what does it cost here?"
+    for the fleet, module and fuel systems.
+*/
+
+package game
+
+import "math"
+
+// Planet service keys used in universe.yaml.
+const (
+	ServiceShipyard    = "shipyard"
+	ServiceFuelDepot   = "fuel_depot"
+	ServiceOutfitting  = "outfitting"
+	ServiceBlackMarket = "black_market"
+	ServiceBank        = "bank"
+)
+
+// HasService reports whether the planet offers the given service.
+func HasService(planetKey, service string) bool {
+	p := GetPlanet(planetKey)
+	if p == nil {
+		return false
+	}
+	for _, s := range p.Services {
+		if s == service {
+			return true
+		}
+	}
+	return false
+}
+
+// IsShipyard reports whether ships can be bought and sold at the planet.
+func IsShipyard(planetKey string) bool {
+	return HasService(planetKey, ServiceShipyard)
+}
+
+// IsOutfitter reports whether modules can be bought, fitted or removed at the planet.
+// Black markets double as (disreputable) outfitters.
+func IsOutfitter(planetKey string) bool {
+	return HasService(planetKey, ServiceOutfitting) || HasService(planetKey, ServiceBlackMarket)
+}
+
+// LocalPrice applies the planet's shipyard markup to a list price.
+func LocalPrice(planetKey string, listPrice int) int {
+	p := GetPlanet(planetKey)
+	if p == nil || p.Shipyard.PriceMult <= 0 {
+		return listPrice
+	}
+	return int(math.Round(float64(listPrice) * p.Shipyard.PriceMult))
+}
+
+// StocksShip reports whether the planet's shipyard sells the ship template.
+func StocksShip(planetKey, templateKey string) bool {
+	p := GetPlanet(planetKey)
+	if p == nil || !IsShipyard(planetKey) {
+		return false
+	}
+	return len(p.Shipyard.Ships) == 0 || containsKey(p.Shipyard.Ships, templateKey)
+}
+
+// StocksModule reports whether the planet's outfitter sells the module.
+// Restricted modules are only ever sold on a black market.
+func StocksModule(planetKey, moduleKey string) bool {
+	p := GetPlanet(planetKey)
+	mod := GetModule(moduleKey)
+	if p == nil || mod == nil || !IsOutfitter(planetKey) {
+		return false
+	}
+	if mod.Restricted && !HasService(planetKey, ServiceBlackMarket) {
+		return false
+	}
+	return len(p.Shipyard.Modules) == 0 || containsKey(p.Shipyard.Modules, moduleKey)
+}
+
+// GetLocalShips lists the ship templates for sale at a planet, at local prices.
+func GetLocalShips(planetKey string) []ShipTemplate {
+	stock := []ShipTemplate{}
+	for _, t := range CurrentUniverse.ShipTemplates {
+		if t.Cost > 0 && StocksShip(planetKey, t.Key) {
+			t.Cost = LocalPrice(planetKey, t.Cost)
+			stock = append(stock, t)
+		}
+	}
+	return stock
+}
+
+// GetLocalModules lists the modules for sale at a planet, at local prices.
+func GetLocalModules(planetKey string) []ShipModule {
+	stock := []ShipModule{}
+	for _, m := range CurrentUniverse.ShipModules {
+		if StocksModule(planetKey, m.Key) {
+			m.Cost = LocalPrice(planetKey, m.Cost)
+			stock = append(stock, m)
+		}
+	}
+	return stock
+}
+
+// containsKey reports whether key is in list.
+func containsKey(list []string, key string) bool {
+	for _, k := range list {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
@@ -1,6 +1,7 @@
 package game
 
 import (
+	"errors"
 	"os"
 	"sync"
 
@@ -13,6 +14,16 @@ var (
 	CurrentPlayer      Player // Replaces PlayerShip
 	AvailableContracts map[string][]Contract
 	Market             MarketState
+	MarketHistory      map[string]map[string]*PriceHistory // [PlanetKey][ItemKey]
+	GameClock          int64                               // Market ticks elapsed this game
+	ActiveMarketEvents []MarketEvent
+	NewsFeed           []NewsItem
+	NPCTraders         []*NPCTrader
+	Rivals             []*NPCTrader
+	AILevel            string // Key of the AIDifficulty chosen for this game
+	TripLog            []TripRecord
+	Ledger             []LedgerEntry
+	CurrentSession     int // Play sessions this game, counting the current one
 	DataLock           sync.RWMutex
 )
 
@@ -31,6 +42,9 @@ func LoadConfig() error {
 	if err := yaml.Unmarshal(data, &CurrentUniverse); err != nil {
 		return err
 	}
+	if err := ValidateArrivalEvents(data); err != nil {
+		return err
+	}
 
 	// 3. Initialize Runtime State (New Game)
 	// Create the default player
@@ -56,32 +70,99 @@ func LoadConfig() error {
 		starterTemplate = CurrentUniverse.ShipTemplates[0]
 	}
 
-	startingShip := &Ship{
-		InstanceID:       "ship_1",
-		TemplateKey:      starterTemplate.Key,
-		Name:             "SS " + starterTemplate.Name,
-		LocationKey:      "planet_prime",
-		Fuel:             starterTemplate.MaxFuel,
-		MaxFuel:          starterTemplate.MaxFuel,
-		BaseBurnRate:     starterTemplate.BaseBurnRate,
-		BurnDamping:      starterTemplate.BurnDamping,
-		BaseMass:         starterTemplate.BaseMass,
-		CargoCapacity:    starterTemplate.CargoCapacity,
-		PassengerSlots:   starterTemplate.PassengerSlots,
-		MaxModuleSlots:   starterTemplate.MaxModuleSlots,
-		InstalledModules: []ShipModule{},
-		ActiveContracts:  []Contract{},
-	}
+	startingShip := NewShipFromTemplate(&starterTemplate, "ship_1", "SS "+starterTemplate.Name, "planet_prime")
 	CurrentPlayer.Ships["ship_1"] = startingShip
+	TripLog = []TripRecord{}
+	Ledger = []LedgerEntry{}
+	CurrentSession = 1
+
+	// 4. Initialize Market, Job Boards and NPC Traffic
+	resetWorld()
+
+	return nil
+}
+
+// ResetWorld wipes the sector back to a fresh game: markets, history, the
+// clock, events, news, job boards and NPC traffic. Rivals join when a game
+// is created.
+func ResetWorld() {
+	DataLock.Lock()
+	defer DataLock.Unlock()
+	resetWorld()
+}
 
-	// 4. Initialize Market
+// resetWorld is ResetWorld without locking.
+// Note: Caller must hold DataLock
+func resetWorld() {
 	Market = MarketState{
 		SourceHeat: make(map[string]map[string]float64),
 		DestHeat:   make(map[string]map[string]float64),
 	}
+	InitMarket()
+	MarketHistory = make(map[string]map[string]*PriceHistory)
+	GameClock = 0
+	ActiveMarketEvents = []MarketEvent{}
+	NewsFeed = []NewsItem{}
+	newsOutbox = nil
 
-	// 5. Initialize Job Boards
 	AvailableContracts = make(map[string][]Contract)
 
+	spawnNPCTraders()
+	Rivals = []*NPCTrader{}
+	AILevel = CurrentUniverse.RivalConfig.DefaultDifficulty
+}
+
+// InitializeNewPlayer replaces the current player with a fresh captain
+// flying a new ship of the chosen template, docked at Prime.
+func InitializeNewPlayer(playerName, shipName, shipTypeKey string) error {
+	DataLock.Lock()
+	defer DataLock.Unlock()
+
+	template := GetShipTemplate(shipTypeKey)
+	if template == nil {
+		return errors.New("unknown ship type: " + shipTypeKey)
+	}
+	if shipName == "" {
+		shipName = "SS " + template.Name
+	}
+
+	CurrentPlayer = Player{
+		Name:          playerName,
+		Ships:         make(map[string]*Ship),
+		ActiveShipKey: "ship_1",
+	}
+	CurrentPlayer.Ships["ship_1"] = NewShipFromTemplate(template, "ship_1", shipName, "planet_prime")
+	TripLog = []TripRecord{}
+	Ledger = []LedgerEntry{}
+	CurrentSession = 1
+	Transact(LedgerOpening, CurrentUniverse.BalanceConfig.StartingCredits, LedgerRef{
+		ShipID:      "ship_1",
+		PlanetKey:   "planet_prime",
+		Description: "Starting credits",
+	})
+
 	return nil
 }
+
+// NewShipFromTemplate builds a fresh, fully fuelled ship instance from a template.
+func NewShipFromTemplate(t *ShipTemplate, instanceID, name, locationKey string) *Ship {
+	return &Ship{
+		InstanceID:       instanceID,
+		TemplateKey:      t.Key,
+		Name:             name,
+		LocationKey:      locationKey,
+		Fuel:             t.MaxFuel,
+		MaxFuel:          t.MaxFuel,
+		BaseBurnRate:     t.BaseBurnRate,
+		BurnDamping:      t.BurnDamping,
+		BaseMass:         t.BaseMass,
+		CargoCapacity:    t.CargoCapacity,
+		PassengerSlots:   t.PassengerSlots,
+		MaxModuleSlots:   len(TemplateSlots(t)),
+		Hull:             t.MaxHull,
+		MaxHull:          t.MaxHull,
+		Slots:            TemplateSlots(t),
+		InstalledModules: []ShipModule{},
+		ActiveContracts:  []Contract{},
+	}
+}